@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/external"
+	"github.com/hilmiikhsan/library-author-service/external/proto/tokenvalidation"
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/cache"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+	"github.com/hilmiikhsan/library-author-service/internal/policy"
+)
+
+// accessPolicy maps roles to permissions for every server in the process.
+// It is read from AUTH_POLICY_FILE, or built in when that is unset.
+var accessPolicy = sync.OnceValue(func() *policy.Policy {
+	p, err := policy.Load(helpers.GetEnv("AUTH_POLICY_FILE", ""))
+	if err != nil {
+		helpers.Logger.Fatal("failed to load access policy: ", err)
+	}
+
+	return p
+})
+
+// authBreaker guards the auth service for the whole process and is reported
+// by the health check.
+var authBreaker = sync.OnceValue(func() *external.CircuitBreaker {
+	return &external.CircuitBreaker{
+		FailureThreshold: helpers.GetEnvInt("AUTH_BREAKER_FAILURE_THRESHOLD", 5),
+		OpenTimeout:      helpers.GetEnvDuration("AUTH_BREAKER_OPEN_TIMEOUT", 30*time.Second),
+	}
+})
+
+// tokenVerifier is shared by every server in the process so the JWKS is
+// cached once and the auth service is reached over a single connection.
+// AUTH_TOKEN_VERIFIER picks "grpc", the auth service, or "jwks", local JWT
+// verification that falls back to the auth service unless
+// AUTH_TOKEN_FALLBACK is "none".
+var tokenVerifier = sync.OnceValue(func() interfaces.IExternal {
+	if helpers.GetEnv("AUTH_TOKEN_VERIFIER", "grpc") != "jwks" {
+		return newRemoteVerifier()
+	}
+
+	local := &external.JWTVerifier{
+		Keys: &external.JWKSKeySet{
+			Source: helpers.GetEnv("AUTH_JWKS_SOURCE", ""),
+			HTTPClient: &http.Client{
+				Timeout: helpers.GetEnvDuration("AUTH_JWKS_TIMEOUT", 5*time.Second),
+			},
+			RefreshInterval:    helpers.GetEnvDuration("AUTH_JWKS_REFRESH_INTERVAL", 15*time.Minute),
+			MinRefreshInterval: helpers.GetEnvDuration("AUTH_JWKS_MIN_REFRESH_INTERVAL", 30*time.Second),
+			Logger:             helpers.Logger,
+		},
+		Claims: external.JWTClaims{
+			UserID:   helpers.GetEnv("AUTH_JWT_USER_ID_CLAIM", "sub"),
+			Username: helpers.GetEnv("AUTH_JWT_USERNAME_CLAIM", "username"),
+			FullName: helpers.GetEnv("AUTH_JWT_FULL_NAME_CLAIM", "full_name"),
+			Role:     helpers.GetEnv("AUTH_JWT_ROLE_CLAIM", "role"),
+		},
+		Issuer:   helpers.GetEnv("AUTH_JWT_ISSUER", ""),
+		Audience: helpers.GetEnv("AUTH_JWT_AUDIENCE", ""),
+		Leeway:   helpers.GetEnvDuration("AUTH_JWT_LEEWAY", 30*time.Second),
+		Logger:   helpers.Logger,
+	}
+
+	if helpers.GetEnv("AUTH_TOKEN_FALLBACK", "grpc") == "none" {
+		return local
+	}
+
+	return &external.FallbackVerifier{
+		Primary:  local,
+		Fallback: newRemoteVerifier(),
+		Logger:   helpers.Logger,
+	}
+})
+
+// newRemoteVerifier connects to the auth service at startup; the connection
+// is kept for the life of the process.
+func newRemoteVerifier() *external.External {
+	target := helpers.GetEnv("AUTH_GRPC_HOST", "")
+
+	conn, err := external.DialAuthService(
+		target,
+		authServiceCredentials(target),
+		helpers.GetEnvDuration("AUTH_GRPC_KEEPALIVE", 5*time.Minute),
+	)
+	if err != nil {
+		helpers.Logger.Fatal("failed to create auth service client: ", err)
+	}
+
+	return &external.External{
+		Client:  tokenvalidation.NewTokenValidationClient(conn),
+		Breaker: authBreaker(),
+		Cache: &cache.TokenCache{
+			Redis:  helpers.RedisClient,
+			Logger: helpers.Logger,
+			Prefix: helpers.GetEnv("AUTHOR_CACHE_PREFIX", "library_author"),
+			TTL:    helpers.GetEnvDuration("AUTH_TOKEN_CACHE_TTL", time.Minute),
+		},
+		Timeout:      helpers.GetEnvDuration("AUTH_GRPC_TIMEOUT", 2*time.Second),
+		MaxRetries:   helpers.GetEnvInt("AUTH_GRPC_MAX_RETRIES", 2),
+		RetryBackoff: helpers.GetEnvDuration("AUTH_GRPC_RETRY_BACKOFF", 100*time.Millisecond),
+		Logger:       helpers.Logger,
+	}
+}
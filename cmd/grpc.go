@@ -2,10 +2,16 @@ package cmd
 
 import (
 	"net"
+	"net/netip"
+	"time"
 
 	"github.com/hilmiikhsan/library-author-service/cmd/proto/author"
+	"github.com/hilmiikhsan/library-author-service/constants"
 	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/cache"
 	api "github.com/hilmiikhsan/library-author-service/internal/grpc"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
 	authorRepository "github.com/hilmiikhsan/library-author-service/internal/repository/author"
 	authorServices "github.com/hilmiikhsan/library-author-service/internal/services/author"
 	"github.com/hilmiikhsan/library-author-service/internal/validator"
@@ -21,7 +27,11 @@ func ServeGRPC() {
 		helpers.Logger.Fatal("failed to listen grpc port: ", err)
 	}
 
-	server := grpc.NewServer()
+	server := grpc.NewServer(
+		grpc.Creds(grpcServerCredentials()),
+		grpc.UnaryInterceptor(dependencyGrpc.UnaryAuthInterceptor),
+		grpc.StreamInterceptor(dependencyGrpc.StreamAuthInterceptor),
+	)
 	author.RegisterAuthorServiceServer(server, dependencyGrpc.AuthorAPI)
 
 	helpers.Logger.Info("start listening grpc on port:" + helpers.GetEnv("GRPC_PORT", "6002"))
@@ -33,18 +43,34 @@ func ServeGRPC() {
 type DependencyGrpc struct {
 	Logger    *logrus.Logger
 	AuthorAPI *api.AuthorAPI
+	External  interfaces.IExternal
+	Policy    interfaces.IPolicy
+
+	// Callers from TrustedCallers may omit a token and act as TrustedRole;
+	// callers with a client certificate listed in ClientIdentities act as
+	// that identity.
+	TrustedCallers   []netip.Prefix
+	TrustedRole      string
+	ClientIdentities map[string]models.TokenData
 }
 
 func dependencyGrpcInject() *DependencyGrpc {
 	authorRepo := &authorRepository.AuthorRepository{
 		DB:     helpers.DB,
 		Logger: helpers.Logger,
+		Cache: &cache.AuthorCache{
+			Redis:  helpers.RedisClient,
+			Logger: helpers.Logger,
+			Prefix: helpers.GetEnv("AUTHOR_CACHE_PREFIX", "library_author"),
+			TTL:    helpers.GetEnvDuration("AUTHOR_CACHE_TTL", 5*time.Minute),
+		},
 	}
 
 	validator := validator.NewValidator()
 
 	AuthorSvc := &authorServices.AuthorService{
 		AuthorRepo: authorRepo,
+		Notifier:   authorEventNotifier(),
 		Logger:     helpers.Logger,
 	}
 	AuthorAPI := &api.AuthorAPI{
@@ -52,8 +78,25 @@ func dependencyGrpcInject() *DependencyGrpc {
 		Validator:     validator,
 	}
 
+	trustedCallers, err := parseTrustedCallers(helpers.GetEnv("AUTH_GRPC_TRUSTED_CALLERS", ""))
+	if err != nil {
+		helpers.Logger.Fatal("failed to parse AUTH_GRPC_TRUSTED_CALLERS: ", err)
+	}
+
+	trustedRole := helpers.GetEnv("AUTH_GRPC_TRUSTED_ROLE", constants.AuthRoleService)
+
+	clientIdentities, err := parseClientIdentities(helpers.GetEnv("GRPC_TLS_CLIENT_IDENTITIES", ""), trustedRole)
+	if err != nil {
+		helpers.Logger.Fatal("failed to parse GRPC_TLS_CLIENT_IDENTITIES: ", err)
+	}
+
 	return &DependencyGrpc{
-		Logger:    helpers.Logger,
-		AuthorAPI: AuthorAPI,
+		Logger:           helpers.Logger,
+		AuthorAPI:        AuthorAPI,
+		External:         tokenVerifier(),
+		Policy:           accessPolicy(),
+		TrustedCallers:   trustedCallers,
+		TrustedRole:      trustedRole,
+		ClientIdentities: clientIdentities,
 	}
 }
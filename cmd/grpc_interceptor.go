@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/external"
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// grpcMethodPermissions lists what each AuthorService method requires, the
+// gRPC counterpart of the per-route permissions in ServeHTTP. A method that
+// is not listed is denied.
+var grpcMethodPermissions = map[string][]string{
+	"/author.AuthorService/GetDetailAuthor": {constants.PermissionAuthorRead},
+	"/author.AuthorService/CreateAuthor":    {constants.PermissionAuthorWrite},
+	"/author.AuthorService/ListAuthors":     {constants.PermissionAuthorRead},
+	"/author.AuthorService/UpdateAuthor":    {constants.PermissionAuthorWrite},
+	"/author.AuthorService/DeleteAuthor":    {constants.PermissionAuthorDelete},
+	"/author.AuthorService/BatchGetAuthors": {constants.PermissionAuthorRead},
+	"/author.AuthorService/SearchAuthors":   {constants.PermissionAuthorRead},
+	"/author.AuthorService/WatchAuthors":    {constants.PermissionAuthorRead},
+}
+
+func (d *DependencyGrpc) UnaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := d.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (d *DependencyGrpc) StreamAuthInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := d.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authServerStream{ServerStream: stream, ctx: ctx})
+}
+
+// authServerStream hands the authenticated context to stream handlers.
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate resolves the caller of fullMethod, checks its permissions and
+// returns ctx carrying its TokenData. A Bearer token in the authorization
+// metadata is validated like on HTTP. Without one, a verified client
+// certificate with a SAN in ClientIdentities gets in as that identity, and
+// a peer on the trusted caller allowlist as TrustedRole.
+func (d *DependencyGrpc) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	tokenData, err := d.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, ok := grpcMethodPermissions[fullMethod]
+	if !ok || !d.Policy.Allows(tokenData.Role, permissions...) {
+		d.Logger.Errorf("api::authenticate - role %q may not call %s", tokenData.Role, fullMethod)
+		return nil, status.Error(codes.PermissionDenied, constants.ErrAuthRolePermission)
+	}
+
+	return helpers.ContextWithTokenData(ctx, tokenData), nil
+}
+
+func (d *DependencyGrpc) caller(ctx context.Context) (models.TokenData, error) {
+	var (
+		res models.TokenData
+	)
+
+	authHeader := metadata.ValueFromIncomingContext(ctx, strings.ToLower(constants.HeaderAuthorization))
+	if len(authHeader) == 0 {
+		if identity, ok := d.certIdentity(ctx); ok {
+			return identity, nil
+		}
+
+		if addr, ok := d.trustedPeer(ctx); ok {
+			res.UserID = "internal:" + addr.String()
+			res.Username = "internal"
+			res.Role = d.TrustedRole
+			return res, nil
+		}
+
+		d.Logger.Error("api::caller - authorization empty")
+		return res, status.Error(codes.Unauthenticated, constants.ErrAuthorizationIsEmpty)
+	}
+
+	token := helpers.ExtractBearerToken(authHeader[0])
+	if token == "" {
+		d.Logger.Error("api::caller - invalid bearer token format")
+		return res, status.Error(codes.Unauthenticated, constants.ErrInvalidAuthorizationFormat)
+	}
+
+	res, err := d.External.ValidateToken(ctx, token)
+	if err != nil {
+		d.Logger.Error("api::caller - failed to validate token: ", err)
+		if errors.Is(err, external.ErrVerifierUnavailable) {
+			// Let clients retry rather than drop a possibly valid token.
+			return res, status.Error(codes.Unavailable, constants.ErrInvalidAuthorization)
+		}
+		return res, status.Error(codes.Unauthenticated, constants.ErrInvalidAuthorization)
+	}
+
+	return res, nil
+}
+
+// certIdentity maps the SANs of a verified client certificate to a service
+// identity. URI SANs are tried before DNS SANs.
+func (d *DependencyGrpc) certIdentity(ctx context.Context) (models.TokenData, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return models.TokenData{}, false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return models.TokenData{}, false
+	}
+
+	leaf := tlsInfo.State.VerifiedChains[0][0]
+
+	sans := make([]string, 0, len(leaf.URIs)+len(leaf.DNSNames))
+	for _, uri := range leaf.URIs {
+		sans = append(sans, uri.String())
+	}
+	sans = append(sans, leaf.DNSNames...)
+
+	for _, san := range sans {
+		if identity, ok := d.ClientIdentities[san]; ok {
+			return identity, true
+		}
+	}
+
+	return models.TokenData{}, false
+}
+
+// trustedPeer reports whether the call comes from an allowlisted address.
+func (d *DependencyGrpc) trustedPeer(ctx context.Context) (netip.Addr, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return netip.Addr{}, false
+	}
+
+	tcpAddr, ok := p.Addr.(*net.TCPAddr)
+	if !ok {
+		return netip.Addr{}, false
+	}
+
+	addr, ok := netip.AddrFromSlice(tcpAddr.IP)
+	if !ok {
+		return netip.Addr{}, false
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range d.TrustedCallers {
+		if prefix.Contains(addr) {
+			return addr, true
+		}
+	}
+
+	return netip.Addr{}, false
+}
+
+// parseTrustedCallers reads a comma separated list of CIDRs or single
+// addresses.
+func parseTrustedCallers(value string) ([]netip.Prefix, error) {
+	var res []netip.Prefix
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if !strings.Contains(item, "/") {
+			addr, err := netip.ParseAddr(item)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(item)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, prefix.Masked())
+	}
+
+	return res, nil
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
-	"github.com/hilmiikhsan/library-author-service/external"
+	"github.com/hilmiikhsan/library-author-service/constants"
 	"github.com/hilmiikhsan/library-author-service/helpers"
 	authorAPI "github.com/hilmiikhsan/library-author-service/internal/api/author"
 	healthCheckAPI "github.com/hilmiikhsan/library-author-service/internal/api/health_check"
+	importJobAPI "github.com/hilmiikhsan/library-author-service/internal/api/import_job"
+	webhookAPI "github.com/hilmiikhsan/library-author-service/internal/api/webhook"
+	"github.com/hilmiikhsan/library-author-service/internal/cache"
 	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
 	authorRepository "github.com/hilmiikhsan/library-author-service/internal/repository/author"
 	authorServices "github.com/hilmiikhsan/library-author-service/internal/services/author"
@@ -22,11 +27,32 @@ func ServeHTTP() {
 	router.GET("/health", dependency.HealthcheckAPI.HealthcheckHandlerHTTP)
 
 	authorV1 := router.Group("/author/v1")
-	authorV1.POST("/create", dependency.MiddlewareValidateToken, dependency.AuthorAPI.CreateAuthor)
-	authorV1.GET("/:id", dependency.MiddlewareValidateToken, dependency.AuthorAPI.GetDetailAuthor)
-	authorV1.GET("/", dependency.MiddlewareValidateToken, dependency.AuthorAPI.GetListAuthor)
-	authorV1.PUT("/update", dependency.MiddlewareValidateToken, dependency.AuthorAPI.UpdateAuthor)
-	authorV1.DELETE("/:id", dependency.MiddlewareValidateToken, dependency.AuthorAPI.DeleteAuthor)
+	authorV1.POST("/webhooks", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionWebhookManage), dependency.WebhookAPI.CreateWebhook)
+	authorV1.GET("/webhooks", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionWebhookManage), dependency.WebhookAPI.GetListWebhook)
+	authorV1.DELETE("/webhooks/:id", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionWebhookManage), dependency.WebhookAPI.DeleteWebhook)
+	authorV1.GET("/webhooks/:id/deliveries", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionWebhookManage), dependency.WebhookAPI.GetWebhookDeliveries)
+	authorV1.GET("/webhooks/dead-letters", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionWebhookManage), dependency.WebhookAPI.GetDeadLetters)
+	authorV1.POST("/webhooks/deliveries/:id/retry", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionWebhookManage), dependency.WebhookAPI.RetryWebhookDelivery)
+	authorV1.POST("/create", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorWrite), dependency.AuthorAPI.CreateAuthor)
+	authorV1.GET("/search", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorRead), dependency.AuthorAPI.SearchAuthors)
+	authorV1.GET("/events", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorRead), dependency.AuthorAPI.StreamAuthorEvents)
+	authorV1.POST("/batch", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorRead), dependency.AuthorAPI.BatchGetAuthors)
+	authorV1.POST("/import", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorImport), dependency.AuthorAPI.ImportAuthors)
+	authorV1.GET("/export", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorRead), dependency.AuthorAPI.ExportAuthors)
+	authorV1.GET("/duplicates", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorRead), dependency.AuthorAPI.GetDuplicateCandidates)
+	authorV1.POST("/merge", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorWrite, constants.PermissionAuthorDelete), dependency.AuthorAPI.MergeAuthors)
+	authorV1.GET("/jobs/:id", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorImport), dependency.ImportJobAPI.GetImportJob)
+	authorV1.POST("/jobs/:id/cancel", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorImport), dependency.ImportJobAPI.CancelImportJob)
+	authorV1.GET("/jobs/:id/errors", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorImport), dependency.ImportJobAPI.DownloadImportJobErrors)
+	authorV1.GET("/:id", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorRead), dependency.AuthorAPI.GetDetailAuthor)
+	authorV1.GET("/", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorRead), dependency.AuthorAPI.GetListAuthor)
+	authorV1.PUT("/update", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorWrite), dependency.AuthorAPI.UpdateAuthor)
+	authorV1.PATCH("/:id", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorWrite), dependency.AuthorAPI.PatchAuthor)
+	authorV1.DELETE("/:id", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorDelete), dependency.AuthorAPI.DeleteAuthor)
+	authorV1.POST("/:id/restore", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorDelete), dependency.AuthorAPI.RestoreAuthor)
+	authorV1.GET("/:id/history", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorRead), dependency.AuthorAPI.GetAuthorHistory)
+	authorV1.GET("/:id/history/:version", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorRead), dependency.AuthorAPI.GetAuthorVersion)
+	authorV1.POST("/:id/history/:version/revert", dependency.MiddlewareValidateToken, dependency.MiddlewarePermission(constants.PermissionAuthorWrite), dependency.AuthorAPI.RevertAuthor)
 
 	err := router.Run(":" + helpers.GetEnv("PORT", ""))
 	if err != nil {
@@ -40,13 +66,18 @@ type Dependency struct {
 
 	HealthcheckAPI interfaces.IHealthcheckHandler
 	AuthorAPI      interfaces.IAuthorHandler
+	WebhookAPI     interfaces.IWebhookHandler
+	ImportJobAPI   interfaces.IImportJobHandler
 	External       interfaces.IExternal
+	Policy         interfaces.IPolicy
 }
 
 func dependencyInject() Dependency {
 	helpers.SetupLogger()
 
-	healthcheckSvc := &healthCheckServices.Healthcheck{}
+	healthcheckSvc := &healthCheckServices.Healthcheck{
+		AuthBreaker: authBreaker(),
+	}
 	healthcheckAPI := &healthCheckAPI.Healthcheck{
 		HealthcheckServices: healthcheckSvc,
 	}
@@ -54,22 +85,41 @@ func dependencyInject() Dependency {
 	authorRepo := &authorRepository.AuthorRepository{
 		DB:     helpers.DB,
 		Logger: helpers.Logger,
-		Redis:  helpers.RedisClient,
+		Cache: &cache.AuthorCache{
+			Redis:  helpers.RedisClient,
+			Logger: helpers.Logger,
+			Prefix: helpers.GetEnv("AUTHOR_CACHE_PREFIX", "library_author"),
+			TTL:    helpers.GetEnvDuration("AUTHOR_CACHE_TTL", 5*time.Minute),
+		},
 	}
 
 	validator := validator.NewValidator()
 
+	webhookSvc := newWebhookService()
+	webhookAPI := &webhookAPI.WebhookHandler{
+		WebhookService: webhookSvc,
+		Validator:      validator,
+	}
+
 	authorSvc := &authorServices.AuthorService{
 		AuthorRepo: authorRepo,
+		Notifier:   authorEventNotifier(),
 		Logger:     helpers.Logger,
 	}
-	authorAPI := &authorAPI.AuthorHandler{
-		AuthorService: authorSvc,
-		Validator:     validator,
+	importJobSvc := newImportJobService(authorSvc)
+	importJobAPI := &importJobAPI.ImportJobHandler{
+		ImportJobService: importJobSvc,
 	}
 
-	external := &external.External{
-		Logger: helpers.Logger,
+	authorAPI := &authorAPI.AuthorHandler{
+		AuthorService:     authorSvc,
+		ImportJobService:  importJobSvc,
+		Policy:            accessPolicy(),
+		Validator:         validator,
+		EventHeartbeat:    helpers.GetEnvDuration("AUTHOR_EVENT_HEARTBEAT", 15*time.Second),
+		EventWriteTimeout: helpers.GetEnvDuration("AUTHOR_EVENT_WRITE_TIMEOUT", 10*time.Second),
+		ImportMaxBytes:    int64(helpers.GetEnvInt("AUTHOR_IMPORT_MAX_BYTES", 32<<20)),
+		ImportMaxRows:     helpers.GetEnvInt("AUTHOR_IMPORT_MAX_ROWS", 50000),
 	}
 
 	return Dependency{
@@ -77,6 +127,9 @@ func dependencyInject() Dependency {
 		AuthorRepository: authorRepo,
 		HealthcheckAPI:   healthcheckAPI,
 		AuthorAPI:        authorAPI,
-		External:         external,
+		WebhookAPI:       webhookAPI,
+		ImportJobAPI:     importJobAPI,
+		External:         tokenVerifier(),
+		Policy:           accessPolicy(),
 	}
 }
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/cache"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+	importJobRepository "github.com/hilmiikhsan/library-author-service/internal/repository/import_job"
+	importJobServices "github.com/hilmiikhsan/library-author-service/internal/services/import_job"
+)
+
+// importPendingLimit caps how many pending jobs are considered per poll.
+const importPendingLimit = 100
+
+// RunImportWorkers polls for queued import jobs every
+// AUTHOR_IMPORT_POLL_INTERVAL and runs up to AUTHOR_IMPORT_WORKERS of them
+// at once. A Redis lock per job keeps replicas from running the same job.
+func RunImportWorkers() {
+	importJobSvc := dependencyImportInject()
+
+	interval := helpers.GetEnvDuration("AUTHOR_IMPORT_POLL_INTERVAL", 2*time.Second)
+	workers := helpers.GetEnvInt("AUTHOR_IMPORT_WORKERS", 2)
+
+	helpers.Logger.Infof("start import workers every %s with %d workers", interval, workers)
+
+	var (
+		slots   = make(chan struct{}, workers)
+		mu      sync.Mutex
+		running = make(map[string]struct{})
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		ids, err := importJobSvc.FindPendingImportJobIDs(context.Background(), importPendingLimit)
+		if err != nil {
+			helpers.Logger.Error("import::RunImportWorkers - failed to find pending import jobs: ", err)
+			continue
+		}
+
+	dispatch:
+		for _, id := range ids {
+			mu.Lock()
+			_, busy := running[id]
+			mu.Unlock()
+			if busy {
+				continue
+			}
+
+			select {
+			case slots <- struct{}{}:
+			default:
+				break dispatch
+			}
+
+			mu.Lock()
+			running[id] = struct{}{}
+			mu.Unlock()
+
+			go func(id string) {
+				defer func() {
+					mu.Lock()
+					delete(running, id)
+					mu.Unlock()
+					<-slots
+				}()
+
+				if err := importJobSvc.ProcessImportJob(context.Background(), id); err != nil {
+					helpers.Logger.Error("import::RunImportWorkers - failed to process import job: ", err)
+				}
+			}(id)
+		}
+	}
+}
+
+// Chunk sizes and lock TTLs below these fall back to the defaults: a chunk
+// of zero rows never advances the job, and the lock is refreshed every third
+// of its TTL.
+const (
+	defaultImportChunkSize = 500
+	defaultImportLockTTL   = 30 * time.Second
+	minImportLockTTL       = time.Second
+)
+
+func newImportJobService(authorSvc interfaces.IAuthorService) *importJobServices.ImportJobService {
+	chunkSize := helpers.GetEnvInt("AUTHOR_IMPORT_CHUNK_SIZE", defaultImportChunkSize)
+	if chunkSize <= 0 {
+		helpers.Logger.Warnf("AUTHOR_IMPORT_CHUNK_SIZE must be positive, using %d", defaultImportChunkSize)
+		chunkSize = defaultImportChunkSize
+	}
+
+	lockTTL := helpers.GetEnvDuration("AUTHOR_IMPORT_LOCK_TTL", defaultImportLockTTL)
+	if lockTTL < minImportLockTTL {
+		helpers.Logger.Warnf("AUTHOR_IMPORT_LOCK_TTL must be at least %s, using %s", minImportLockTTL, defaultImportLockTTL)
+		lockTTL = defaultImportLockTTL
+	}
+
+	return &importJobServices.ImportJobService{
+		ImportJobRepo: &importJobRepository.ImportJobRepository{
+			DB:     helpers.DB,
+			Logger: helpers.Logger,
+		},
+		AuthorService: authorSvc,
+		Locker: &cache.RedisLocker{
+			Redis:  helpers.RedisClient,
+			Logger: helpers.Logger,
+			Prefix: helpers.GetEnv("AUTHOR_CACHE_PREFIX", "library_author"),
+		},
+		Logger:    helpers.Logger,
+		ChunkSize: chunkSize,
+		LockTTL:   lockTTL,
+	}
+}
+
+func dependencyImportInject() interfaces.IImportJobService {
+	return newImportJobService(dependencyPurgeInject())
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/hilmiikhsan/library-author-service/constants"
 	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
 )
 
 func (d *Dependency) MiddlewareValidateToken(ctx *gin.Context) {
@@ -33,14 +34,26 @@ func (d *Dependency) MiddlewareValidateToken(ctx *gin.Context) {
 		return
 	}
 
-	if tokenData.Role != constants.AuthRoleAdmin {
-		helpers.Logger.Error("middleware::MiddlewareValidateToken - invalid role you do not permission to access this endpoint")
-		ctx.JSON(http.StatusUnauthorized, helpers.Error(constants.ErrAuthRolePermission))
-		ctx.Abort()
-		return
-	}
-
 	ctx.Set(constants.TokenTypeAccess, tokenData)
+	ctx.Request = ctx.Request.WithContext(helpers.ContextWithTokenData(ctx.Request.Context(), tokenData))
 
 	ctx.Next()
 }
+
+// MiddlewarePermission lets the request through only when the policy grants
+// the caller's role every one of permissions. It must run after
+// MiddlewareValidateToken.
+func (d *Dependency) MiddlewarePermission(permissions ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tokenData, _ := helpers.TokenDataFromContext(ctx.Request.Context())
+		if !d.Policy.Allows(tokenData.Role, permissions...) {
+			helpers.Logger.Errorf("middleware::MiddlewarePermission - role %q lacks %v", tokenData.Role, permissions)
+			err := domain.Forbidden(constants.ErrAuthRolePermission)
+			ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/cache"
+	"github.com/hilmiikhsan/library-author-service/internal/events"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+	authorRepository "github.com/hilmiikhsan/library-author-service/internal/repository/author"
+)
+
+// RunOutboxRelay publishes author_events rows every AUTHOR_OUTBOX_INTERVAL,
+// draining the outbox in batches of AUTHOR_OUTBOX_BATCH_SIZE. Failed events
+// stay pending and are retried on the next tick.
+func RunOutboxRelay() {
+	authorRepo, publisher := dependencyOutboxInject()
+
+	interval := helpers.GetEnvDuration("AUTHOR_OUTBOX_INTERVAL", time.Second)
+	batchSize := helpers.GetEnvInt("AUTHOR_OUTBOX_BATCH_SIZE", 100)
+
+	helpers.Logger.Infof("start outbox relay every %s with batch size %d", interval, batchSize)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		for {
+			published, err := authorRepo.RelayAuthorEvents(context.Background(), batchSize, publisher.Publish)
+			if err != nil {
+				helpers.Logger.Error("outbox::RunOutboxRelay - failed to relay author events: ", err)
+				break
+			}
+
+			if published < batchSize {
+				break
+			}
+		}
+	}
+}
+
+// authorEventNotifier is shared by every server in the process so change
+// feeds hold a single LISTEN connection.
+var authorEventNotifier = sync.OnceValue(func() *events.PgNotifier {
+	return &events.PgNotifier{
+		DSN:          helpers.PostgresDSN(),
+		Channel:      "author_events",
+		PollInterval: helpers.GetEnvDuration("AUTHOR_EVENT_POLL_INTERVAL", 5*time.Second),
+		Logger:       helpers.Logger,
+	}
+})
+
+func dependencyOutboxInject() (interfaces.IAuthorRepository, interfaces.IEventPublisher) {
+	authorRepo := &authorRepository.AuthorRepository{
+		DB:     helpers.DB,
+		Logger: helpers.Logger,
+		Cache: &cache.AuthorCache{
+			Redis:  helpers.RedisClient,
+			Logger: helpers.Logger,
+			Prefix: helpers.GetEnv("AUTHOR_CACHE_PREFIX", "library_author"),
+			TTL:    helpers.GetEnvDuration("AUTHOR_CACHE_TTL", 5*time.Minute),
+		},
+	}
+
+	var publisher interfaces.IEventPublisher
+	switch helpers.GetEnv("AUTHOR_EVENT_PUBLISHER", "redis") {
+	case "log":
+		publisher = &events.LogPublisher{
+			Logger: helpers.Logger,
+		}
+	default:
+		publisher = &events.RedisStreamPublisher{
+			Redis:  helpers.RedisClient,
+			Logger: helpers.Logger,
+			Stream: helpers.GetEnv("AUTHOR_EVENT_STREAM", "library_author:events"),
+			MaxLen: int64(helpers.GetEnvInt("AUTHOR_EVENT_STREAM_MAXLEN", 100000)),
+		}
+	}
+
+	return authorRepo, publisher
+}
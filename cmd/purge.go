@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/cache"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+	authorRepository "github.com/hilmiikhsan/library-author-service/internal/repository/author"
+	authorServices "github.com/hilmiikhsan/library-author-service/internal/services/author"
+)
+
+// RunPurgeJob hard-deletes soft-deleted authors once they are older than
+// AUTHOR_PURGE_RETENTION, checking every AUTHOR_PURGE_INTERVAL.
+func RunPurgeJob() {
+	authorSvc := dependencyPurgeInject()
+
+	interval := helpers.GetEnvDuration("AUTHOR_PURGE_INTERVAL", time.Hour)
+	retention := helpers.GetEnvDuration("AUTHOR_PURGE_RETENTION", 30*24*time.Hour)
+
+	helpers.Logger.Infof("start purge job every %s with retention %s", interval, retention)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		purged, err := authorSvc.PurgeDeletedAuthors(context.Background(), retention)
+		if err != nil {
+			helpers.Logger.Error("purge::RunPurgeJob - failed to purge deleted authors: ", err)
+			continue
+		}
+
+		if purged > 0 {
+			helpers.Logger.Infof("purge::RunPurgeJob - purged %d deleted authors", purged)
+		}
+	}
+}
+
+func dependencyPurgeInject() interfaces.IAuthorService {
+	authorRepo := &authorRepository.AuthorRepository{
+		DB:     helpers.DB,
+		Logger: helpers.Logger,
+		Cache: &cache.AuthorCache{
+			Redis:  helpers.RedisClient,
+			Logger: helpers.Logger,
+			Prefix: helpers.GetEnv("AUTHOR_CACHE_PREFIX", "library_author"),
+			TTL:    helpers.GetEnvDuration("AUTHOR_CACHE_TTL", 5*time.Minute),
+		},
+	}
+
+	return &authorServices.AuthorService{
+		AuthorRepo: authorRepo,
+		Notifier:   authorEventNotifier(),
+		Logger:     helpers.Logger,
+	}
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/certs"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// grpcServerCredentials serves TLS when GRPC_TLS_CERT_FILE and
+// GRPC_TLS_KEY_FILE are set and plaintext otherwise. With
+// GRPC_TLS_CLIENT_CA_FILE client certificates are verified too; they are
+// required unless GRPC_TLS_CLIENT_AUTH is "optional", which lets
+// token-bearing clients connect without one.
+func grpcServerCredentials() credentials.TransportCredentials {
+	certFile := helpers.GetEnv("GRPC_TLS_CERT_FILE", "")
+	keyFile := helpers.GetEnv("GRPC_TLS_KEY_FILE", "")
+	if certFile == "" && keyFile == "" {
+		return insecure.NewCredentials()
+	}
+
+	interval := helpers.GetEnvDuration("TLS_RELOAD_INTERVAL", 30*time.Second)
+
+	keyPair, err := certs.NewKeyPair(certFile, keyFile, interval, helpers.Logger)
+	if err != nil {
+		helpers.Logger.Fatal("failed to load grpc server certificate: ", err)
+	}
+
+	var clientCAs *certs.CAPool
+	if caFile := helpers.GetEnv("GRPC_TLS_CLIENT_CA_FILE", ""); caFile != "" {
+		clientCAs, err = certs.NewCAPool(caFile, interval, helpers.Logger)
+		if err != nil {
+			helpers.Logger.Fatal("failed to load grpc client CA: ", err)
+		}
+	}
+
+	clientAuth := tls.RequireAndVerifyClientCert
+	if helpers.GetEnv("GRPC_TLS_CLIENT_AUTH", "require") == "optional" {
+		clientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return credentials.NewTLS(certs.ServerConfig(keyPair, clientCAs, clientAuth))
+}
+
+// authServiceCredentials secures the auth service connection to target when
+// AUTH_GRPC_TLS is "true". AUTH_GRPC_TLS_CA_FILE replaces the system roots,
+// and AUTH_GRPC_TLS_CERT_FILE and AUTH_GRPC_TLS_KEY_FILE add a client
+// certificate for mTLS. The server certificate must match
+// AUTH_GRPC_TLS_SERVER_NAME, or the host of target when it is empty.
+func authServiceCredentials(target string) credentials.TransportCredentials {
+	if helpers.GetEnv("AUTH_GRPC_TLS", "false") != "true" {
+		return insecure.NewCredentials()
+	}
+
+	var (
+		interval = helpers.GetEnvDuration("TLS_RELOAD_INTERVAL", 30*time.Second)
+		keyPair  *certs.KeyPair
+		rootCAs  *certs.CAPool
+		err      error
+	)
+
+	if caFile := helpers.GetEnv("AUTH_GRPC_TLS_CA_FILE", ""); caFile != "" {
+		rootCAs, err = certs.NewCAPool(caFile, interval, helpers.Logger)
+		if err != nil {
+			helpers.Logger.Fatal("failed to load auth service CA: ", err)
+		}
+	}
+
+	certFile := helpers.GetEnv("AUTH_GRPC_TLS_CERT_FILE", "")
+	keyFile := helpers.GetEnv("AUTH_GRPC_TLS_KEY_FILE", "")
+	if certFile != "" || keyFile != "" {
+		keyPair, err = certs.NewKeyPair(certFile, keyFile, interval, helpers.Logger)
+		if err != nil {
+			helpers.Logger.Fatal("failed to load auth service client certificate: ", err)
+		}
+	}
+
+	serverName := helpers.GetEnv("AUTH_GRPC_TLS_SERVER_NAME", "")
+	if serverName == "" {
+		serverName = targetHost(target)
+	}
+
+	return credentials.NewTLS(certs.ClientConfig(keyPair, rootCAs, serverName))
+}
+
+// targetHost returns the host of a gRPC dial target such as "auth:7000" or
+// "dns:///auth:7000".
+func targetHost(target string) string {
+	if i := strings.Index(target, ":///"); i >= 0 {
+		target = target[i+len(":///"):]
+	}
+
+	host, _, err := net.SplitHostPort(target)
+	if err != nil {
+		return target
+	}
+
+	return host
+}
+
+// parseClientIdentities reads GRPC_TLS_CLIENT_IDENTITIES, a comma separated
+// list of "san=name" or "san=name:role" entries mapping a client
+// certificate DNS or URI SAN to a service identity. The role defaults to
+// defaultRole and is what the policy authorizes.
+func parseClientIdentities(value, defaultRole string) (map[string]models.TokenData, error) {
+	res := make(map[string]models.TokenData)
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		i := strings.LastIndex(item, "=")
+		if i <= 0 || i == len(item)-1 {
+			return nil, fmt.Errorf("invalid client identity %q", item)
+		}
+
+		san, name := item[:i], item[i+1:]
+		role := defaultRole
+		if j := strings.Index(name, ":"); j >= 0 {
+			name, role = name[:j], name[j+1:]
+		}
+		if name == "" || role == "" {
+			return nil, fmt.Errorf("invalid client identity %q", item)
+		}
+
+		res[san] = models.TokenData{
+			UserID:   "service:" + name,
+			Username: name,
+			Role:     role,
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	webhookRepository "github.com/hilmiikhsan/library-author-service/internal/repository/webhook"
+	webhookServices "github.com/hilmiikhsan/library-author-service/internal/services/webhook"
+)
+
+// RunWebhookDispatcher sends due webhook deliveries every
+// WEBHOOK_DISPATCH_INTERVAL, draining them in batches of WEBHOOK_BATCH_SIZE.
+func RunWebhookDispatcher() {
+	webhookSvc := newWebhookService()
+
+	interval := helpers.GetEnvDuration("WEBHOOK_DISPATCH_INTERVAL", 5*time.Second)
+	batchSize := helpers.GetEnvInt("WEBHOOK_BATCH_SIZE", 50)
+
+	helpers.Logger.Infof("start webhook dispatcher every %s with batch size %d", interval, batchSize)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		for {
+			claimed, err := webhookSvc.DispatchDueDeliveries(context.Background(), batchSize)
+			if err != nil {
+				helpers.Logger.Error("webhook::RunWebhookDispatcher - failed to dispatch webhooks: ", err)
+				break
+			}
+
+			if claimed < batchSize {
+				break
+			}
+		}
+	}
+}
+
+func newWebhookService() *webhookServices.WebhookService {
+	return &webhookServices.WebhookService{
+		WebhookRepo: &webhookRepository.WebhookRepository{
+			DB:     helpers.DB,
+			Logger: helpers.Logger,
+		},
+		Logger: helpers.Logger,
+		HTTPClient: &http.Client{
+			Timeout: helpers.GetEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			// A redirect would resend the signed payload to a host nobody
+			// registered, so it fails the delivery instead.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		MaxAttempts: helpers.GetEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		BackoffBase: helpers.GetEnvDuration("WEBHOOK_BACKOFF_BASE", 30*time.Second),
+		BackoffMax:  helpers.GetEnvDuration("WEBHOOK_BACKOFF_MAX", time.Hour),
+	}
+}
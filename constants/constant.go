@@ -8,16 +8,102 @@ const (
 	ErrInvalidAuthorizationFormat = "invalid authorization format"
 	ErrInvalidAuthorization       = "invalid authorization"
 	ErrAuthorNotFound             = "author not found"
+	ErrDeletedAuthorNotFound      = "deleted author not found"
+	ErrAuthorVersionNotFound      = "author version not found"
+	ErrInvalidVersion             = "version must be a positive number"
+	ErrAuthorVersionMismatch      = "author has been modified by another request"
+	ErrIfMatchRequired            = "If-Match header is required"
+	ErrInvalidIfMatch             = "If-Match header is not a valid author ETag"
+	ErrInvalidMergePatch          = "request body must be a JSON object"
+	ErrUnknownPatchField          = "field cannot be patched"
+	ErrFieldCannotBeNull          = "field cannot be null"
+	ErrWebhookNotFound            = "webhook subscription not found"
+	ErrDeadLetterNotFound         = "dead-lettered webhook delivery not found"
+	ErrInvalidResumeToken         = "invalid resume token"
+	ErrImportFileRequired         = "import file is required"
+	ErrImportFormat               = "import format must be csv or jsonl"
+	ErrImportTooManyRows          = "import file has too many rows"
+	ErrImportMissingColumn        = "csv header is missing a required column"
+	ErrImportDuplicate            = "author with the same name and birth date already exists"
+	ErrImportFailed               = "failed to insert row"
+	ErrImportJobNotFound          = "import job not found"
+	ErrImportJobFinished          = "import job has already finished"
+	ErrMergeSameAuthor            = "source and target must be different authors"
 	ErrParamIdIsRequired          = "param id is required"
 	ErrIdIsNotValidUUID           = "id is not valid uuid"
 	ErrInvalidFormatDate          = "invalid format date"
 	ErrAuthRolePermission         = "you do not have permission to access this endpoint"
+	ErrInvalidCursor              = "invalid cursor"
+	ErrCursorUnsupportedSort      = "cursor pagination only supports sort=updated_at"
+	ErrCursorOrderMismatch        = "cursor was issued for a different order"
 )
 
 const (
 	HeaderAuthorization = "Authorization"
 	TokenTypeAccess     = "token"
 	DateTimeFormat      = "2006-01-02"
+	TimestampFormat     = "2006-01-02T15:04:05Z07:00"
 	AuthRoleUser        = "User"
 	AuthRoleAdmin       = "Admin"
+	AuthRoleService     = "Service"
+)
+
+const (
+	AuthorOperationCreate  = "create"
+	AuthorOperationUpdate  = "update"
+	AuthorOperationDelete  = "delete"
+	AuthorOperationRestore = "restore"
+	AuthorOperationRevert  = "revert"
+	AuthorOperationMerge   = "merge"
+)
+
+const (
+	AuthorEventCreated  = "author.created"
+	AuthorEventUpdated  = "author.updated"
+	AuthorEventDeleted  = "author.deleted"
+	AuthorEventRestored = "author.restored"
+	AuthorEventPurged   = "author.purged"
+	AuthorEventMerged   = "author.merged"
+)
+
+const (
+	WebhookStatusPending   = "pending"
+	WebhookStatusDelivered = "delivered"
+	WebhookStatusDead      = "dead"
+)
+
+const (
+	ImportStatusCreated   = "created"
+	ImportStatusValid     = "valid"
+	ImportStatusDuplicate = "skipped_duplicate"
+	ImportStatusInvalid   = "invalid"
+	ImportStatusFailed    = "failed"
+)
+
+const (
+	ImportJobStatusQueued    = "queued"
+	ImportJobStatusRunning   = "running"
+	ImportJobStatusCompleted = "completed"
+	ImportJobStatusFailed    = "failed"
+	ImportJobStatusCancelled = "cancelled"
+)
+
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+const (
+	HealthStatusHealthy  = "healthy"
+	HealthStatusDegraded = "degraded"
+)
+
+const (
+	PermissionAuthorRead        = "author:read"
+	PermissionAuthorReadDeleted = "author:read_deleted"
+	PermissionAuthorWrite       = "author:write"
+	PermissionAuthorDelete      = "author:delete"
+	PermissionAuthorImport      = "author:import"
+	PermissionWebhookManage     = "webhook:manage"
 )
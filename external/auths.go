@@ -2,19 +2,54 @@ package external
 
 import (
 	"context"
-	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
 
 	"github.com/hilmiikhsan/library-author-service/constants"
 	"github.com/hilmiikhsan/library-author-service/external/proto/tokenvalidation"
-	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
 	"github.com/hilmiikhsan/library-author-service/internal/models"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
+// ErrInvalidToken means the auth service answered and rejected the token.
+// Unlike ErrVerifierUnavailable it does not count against the breaker.
+var ErrInvalidToken = errors.New("invalid token")
+
+// External validates tokens with the auth service over one long-lived
+// connection. Each attempt gets Timeout; transient failures are retried up
+// to MaxRetries times with jittered exponential backoff from RetryBackoff.
+// Breaker short-circuits calls while the service keeps failing to answer,
+// and Cache, when set, serves tokens the service already accepted.
 type External struct {
-	Logger *logrus.Logger
+	Client       tokenvalidation.TokenValidationClient
+	Breaker      interfaces.ICircuitBreaker
+	Cache        interfaces.ITokenCache
+	Timeout      time.Duration
+	MaxRetries   int
+	RetryBackoff time.Duration
+	Logger       *logrus.Logger
+}
+
+// DialAuthService opens the connection to the auth service. It connects
+// lazily and pings during calls so a dead peer is noticed. gRPC servers
+// reject pings more often than every five minutes by default, so a shorter
+// keepaliveTime needs a matching enforcement policy on the auth service.
+func DialAuthService(target string, creds credentials.TransportCredentials, keepaliveTime time.Duration) (*grpc.ClientConn, error) {
+	return grpc.NewClient(target,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    keepaliveTime,
+			Timeout: 20 * time.Second,
+		}),
+	)
 }
 
 func (e *External) ValidateToken(ctx context.Context, token string) (models.TokenData, error) {
@@ -22,28 +57,41 @@ func (e *External) ValidateToken(ctx context.Context, token string) (models.Toke
 		res models.TokenData
 	)
 
-	conn, err := grpc.Dial(helpers.GetEnv("AUTH_GRPC_HOST", ""), grpc.WithInsecure())
-	if err != nil {
-		e.Logger.Error("external::ValidateToken - failed to dial grpc server: ", err)
-		return res, errors.Wrap(err, "failed to dial ums grpc")
+	if e.Cache != nil {
+		if cached, ok := e.Cache.Get(ctx, token); ok {
+			return cached, nil
+		}
 	}
-	defer conn.Close()
 
-	client := tokenvalidation.NewTokenValidationClient(conn)
+	if !e.Breaker.Allow() {
+		e.Logger.Error("external::ValidateToken - auth service circuit breaker is open")
+		return res, errors.Wrap(ErrVerifierUnavailable, ErrCircuitOpen.Error())
+	}
 
-	req := &tokenvalidation.TokenRequest{
-		Token: token,
+	response, err := e.validate(ctx, token)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// The caller went away; that says nothing about the service.
+		e.Breaker.Release()
+		e.Logger.Error("external::ValidateToken - failed to validate token: ", err)
+		return res, errors.Wrap(ErrVerifierUnavailable, err.Error())
+	case err != nil && transient(err):
+		e.Breaker.Failure()
+		e.Logger.Error("external::ValidateToken - failed to validate token: ", err)
+		return res, errors.Wrap(ErrVerifierUnavailable, err.Error())
 	}
 
-	response, err := client.ValidateToken(ctx, req)
+	// Any other answer, rejections included, shows the service is up.
+	e.Breaker.Success()
+
 	if err != nil {
-		e.Logger.Error("external::ValidateToken - failed to validate token: ", err)
-		return res, errors.Wrap(err, "failed to validate token")
+		e.Logger.Error("external::ValidateToken - token rejected: ", err)
+		return res, errors.Wrap(ErrInvalidToken, status.Convert(err).Message())
 	}
 
 	if response.Message != constants.SuccessMessage {
 		e.Logger.Error("external::ValidateToken - invalid token: ", response.Message)
-		return res, fmt.Errorf("got response error from ums: %s", response.Message)
+		return res, errors.Wrapf(ErrInvalidToken, "got response error from ums: %s", response.Message)
 	}
 
 	res.UserID = response.Data.UserId
@@ -51,5 +99,67 @@ func (e *External) ValidateToken(ctx context.Context, token string) (models.Toke
 	res.FullName = response.Data.FullName
 	res.Role = response.Data.Role
 
+	if e.Cache != nil {
+		e.Cache.Set(ctx, token, res, tokenExpiry(token))
+	}
+
 	return res, nil
 }
+
+func (e *External) validate(ctx context.Context, token string) (*tokenvalidation.TokenResponse, error) {
+	req := &tokenvalidation.TokenRequest{
+		Token: token,
+	}
+
+	backoff := e.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		callCtx, cancel := context.WithTimeout(ctx, e.Timeout)
+		response, err := e.Client.ValidateToken(callCtx, req)
+		cancel()
+
+		if err == nil || attempt >= e.MaxRetries || !transient(err) {
+			return response, err
+		}
+
+		// Full jitter keeps replicas from retrying in lockstep.
+		wait := time.Duration(rand.Int64N(int64(backoff) + 1))
+		e.Logger.Warnf("external::validate - retrying token validation in %s: %v", wait, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// transient reports whether err means the auth service could not answer, as
+// opposed to answering that the token is bad. Only these are retried and
+// count against the breaker.
+func transient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// tokenExpiry reads the exp claim of a JWT without verifying it, which is
+// only safe for a token the auth service has just accepted. Opaque tokens
+// yield the zero time.
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	claims := make(map[string]any)
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return time.Time{}
+	}
+
+	exp, _ := claimTime(claims, "exp")
+	return exp
+}
@@ -0,0 +1,81 @@
+package external
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/external/proto/tokenvalidation"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubTokenClient struct {
+	response *tokenvalidation.TokenResponse
+	err      error
+}
+
+func (c *stubTokenClient) ValidateToken(ctx context.Context, in *tokenvalidation.TokenRequest, opts ...grpc.CallOption) (*tokenvalidation.TokenResponse, error) {
+	return c.response, c.err
+}
+
+func discardLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func TestExternalValidateTokenClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    *tokenvalidation.TokenResponse
+		err         error
+		unavailable bool
+		invalid     bool
+		failures    int
+	}{
+		{
+			name: "accepted",
+			response: &tokenvalidation.TokenResponse{
+				Message: constants.SuccessMessage,
+				Data:    &tokenvalidation.UserData{UserId: "1", Role: "User"},
+			},
+		},
+		{name: "rejected message", response: &tokenvalidation.TokenResponse{Message: "token expired"}, invalid: true},
+		{name: "unauthenticated", err: status.Error(codes.Unauthenticated, "bad token"), invalid: true},
+		{name: "invalid argument", err: status.Error(codes.InvalidArgument, "malformed"), invalid: true},
+		{name: "permission denied", err: status.Error(codes.PermissionDenied, "revoked"), invalid: true},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "down"), unavailable: true, failures: 1},
+		{name: "deadline exceeded", err: status.Error(codes.DeadlineExceeded, "slow"), unavailable: true, failures: 1},
+		{name: "resource exhausted", err: status.Error(codes.ResourceExhausted, "busy"), unavailable: true, failures: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := &CircuitBreaker{FailureThreshold: 5, OpenTimeout: time.Minute}
+			e := &External{
+				Client:  &stubTokenClient{response: tt.response, err: tt.err},
+				Breaker: breaker,
+				Timeout: time.Second,
+				Logger:  discardLogger(),
+			}
+
+			_, err := e.ValidateToken(context.Background(), "token")
+
+			if got := errors.Is(err, ErrVerifierUnavailable); got != tt.unavailable {
+				t.Errorf("errors.Is(err, ErrVerifierUnavailable) = %v, want %v (err: %v)", got, tt.unavailable, err)
+			}
+			if got := errors.Is(err, ErrInvalidToken); got != tt.invalid {
+				t.Errorf("errors.Is(err, ErrInvalidToken) = %v, want %v (err: %v)", got, tt.invalid, err)
+			}
+			if got := breaker.Failures(); got != tt.failures {
+				t.Errorf("breaker failures = %d, want %d", got, tt.failures)
+			}
+		})
+	}
+}
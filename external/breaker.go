@@ -0,0 +1,103 @@
+package external
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/pkg/errors"
+)
+
+// ErrCircuitOpen is returned without calling the auth service while its
+// circuit breaker is open.
+var ErrCircuitOpen = errors.New("auth service circuit breaker is open")
+
+// CircuitBreaker stops calls to a failing dependency. It opens after
+// FailureThreshold consecutive failures and, once OpenTimeout has passed,
+// lets a single probe through; the probe's outcome closes or reopens it.
+type CircuitBreaker struct {
+	FailureThreshold int
+	OpenTimeout      time.Duration
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// Allow reports whether a call may go ahead. A caller that was allowed must
+// report the outcome with Success, Failure or Release.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case constants.BreakerOpen:
+		return false
+	case constants.BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+	}
+
+	return true
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = constants.BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.probing || b.failures >= b.FailureThreshold {
+		b.state = constants.BreakerOpen
+		b.openedAt = time.Now()
+	}
+	b.probing = false
+}
+
+// Release ends a call whose outcome says nothing about the dependency,
+// such as one the caller cancelled.
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// State is one of the constants.Breaker* states.
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.currentState()
+}
+
+// Failures is the number of consecutive failed calls.
+func (b *CircuitBreaker) Failures() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.failures
+}
+
+func (b *CircuitBreaker) currentState() string {
+	if b.state == constants.BreakerOpen && time.Since(b.openedAt) >= b.OpenTimeout {
+		return constants.BreakerHalfOpen
+	}
+	if b.state == "" {
+		return constants.BreakerClosed
+	}
+
+	return b.state
+}
@@ -0,0 +1,30 @@
+package external
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// FallbackVerifier asks Primary first and Fallback only when Primary could
+// not decide, e.g. while the JWKS is unreachable or for opaque tokens. A
+// token Primary rejects is never retried.
+type FallbackVerifier struct {
+	Primary  interfaces.IExternal
+	Fallback interfaces.IExternal
+	Logger   *logrus.Logger
+}
+
+func (v *FallbackVerifier) ValidateToken(ctx context.Context, token string) (models.TokenData, error) {
+	res, err := v.Primary.ValidateToken(ctx, token)
+	if err == nil || !errors.Is(err, ErrVerifierUnavailable) {
+		return res, err
+	}
+
+	v.Logger.Warn("external::ValidateToken - falling back to remote token validation: ", err)
+
+	return v.Fallback.ValidateToken(ctx, token)
+}
@@ -0,0 +1,254 @@
+package external
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// maxJWKSSize bounds the JWKS document read from a file or URL.
+const maxJWKSSize = 1 << 20
+
+// JWKSKeySet caches the signing keys of a JWKS document read from a file
+// path or an http(s) URL. Keys are reloaded once RefreshInterval has passed,
+// and early when a token names an unknown key, at most once per
+// MinRefreshInterval, so rotated keys are picked up without a restart. The
+// last good keys are kept while the source is unreachable.
+type JWKSKeySet struct {
+	Source             string
+	HTTPClient         *http.Client
+	RefreshInterval    time.Duration
+	MinRefreshInterval time.Duration
+	Logger             *logrus.Logger
+
+	mu        sync.RWMutex
+	keys      map[string]jwk
+	fetchedAt time.Time
+
+	refreshMu sync.Mutex
+}
+
+type jwk struct {
+	Alg string
+	Key crypto.PublicKey
+}
+
+type jwksDocument struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		Alg string `json:"alg"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+	} `json:"keys"`
+}
+
+// Key returns the key with kid, reloading the set when it is stale or does
+// not know kid yet.
+func (s *JWKSKeySet) Key(ctx context.Context, kid string) (jwk, error) {
+	key, found, fresh := s.lookup(kid)
+	if found && fresh {
+		return key, nil
+	}
+
+	if err := s.refresh(ctx, !found); err != nil {
+		if found {
+			s.Logger.Warn("external::Key - serving stale JWKS keys: ", err)
+			return key, nil
+		}
+		return jwk{}, errors.Wrap(ErrVerifierUnavailable, err.Error())
+	}
+
+	key, found, _ = s.lookup(kid)
+	if !found {
+		return jwk{}, errors.Wrapf(ErrVerifierUnavailable, "unknown signing key %q", kid)
+	}
+
+	return key, nil
+}
+
+func (s *JWKSKeySet) lookup(kid string) (jwk, bool, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[kid]
+	return key, ok, time.Since(s.fetchedAt) < s.RefreshInterval
+}
+
+// refresh reloads the keys unless another caller just did. A reload for an
+// unknown key is allowed once MinRefreshInterval has passed; a scheduled
+// one once RefreshInterval has.
+func (s *JWKSKeySet) refresh(ctx context.Context, unknownKey bool) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	s.mu.RLock()
+	age := time.Since(s.fetchedAt)
+	loaded := s.keys != nil
+	s.mu.RUnlock()
+
+	if loaded && age < s.MinRefreshInterval {
+		return nil
+	}
+	if loaded && !unknownKey && age < s.RefreshInterval {
+		return nil
+	}
+
+	keys, err := s.load(ctx)
+	if err != nil {
+		s.Logger.Error("external::refresh - failed to load JWKS: ", err)
+		return err
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	s.mu.Unlock()
+
+	s.Logger.Infof("loaded %d JWKS keys from %s", len(keys), s.Source)
+
+	return nil
+}
+
+func (s *JWKSKeySet) load(ctx context.Context) (map[string]jwk, error) {
+	var (
+		body []byte
+		err  error
+	)
+
+	if strings.HasPrefix(s.Source, "http://") || strings.HasPrefix(s.Source, "https://") {
+		body, err = s.fetch(ctx)
+	} else {
+		body, err = os.ReadFile(strings.TrimPrefix(s.Source, "file://"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var doc jwksDocument
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, errors.Wrap(err, "failed to decode JWKS")
+	}
+
+	keys := make(map[string]jwk, len(doc.Keys))
+	for _, raw := range doc.Keys {
+		if raw.Use != "" && raw.Use != "sig" {
+			continue
+		}
+
+		var key jwk
+		switch raw.Kty {
+		case "RSA":
+			key, err = parseRSAKey(raw.N, raw.E)
+		case "EC":
+			key, err = parseECKey(raw.Crv, raw.X, raw.Y)
+		default:
+			continue
+		}
+		if err != nil {
+			s.Logger.Warn(fmt.Sprintf("external::load - skipping JWKS key %q: ", raw.Kid), err)
+			continue
+		}
+
+		if raw.Alg != "" && raw.Alg != key.Alg {
+			s.Logger.Warnf("external::load - skipping JWKS key %q: alg %s does not match key type", raw.Kid, raw.Alg)
+			continue
+		}
+
+		keys[raw.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (s *JWKSKeySet) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.Source, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch JWKS")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+}
+
+func parseRSAKey(n, e string) (jwk, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return jwk{}, errors.Wrap(err, "invalid modulus")
+	}
+
+	exponent, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return jwk{}, errors.Wrap(err, "invalid exponent")
+	}
+
+	pub := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}
+	if pub.N.BitLen() < 2048 || pub.E < 3 {
+		return jwk{}, errors.New("RSA key is too weak")
+	}
+
+	return jwk{Alg: "RS256", Key: pub}, nil
+}
+
+func parseECKey(crv, x, y string) (jwk, error) {
+	if crv != "P-256" {
+		return jwk{}, fmt.Errorf("unsupported curve %q", crv)
+	}
+
+	xBytes, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil || len(xBytes) != 32 {
+		return jwk{}, errors.New("invalid x coordinate")
+	}
+
+	yBytes, err := base64.RawURLEncoding.DecodeString(y)
+	if err != nil || len(yBytes) != 32 {
+		return jwk{}, errors.New("invalid y coordinate")
+	}
+
+	// ecdh rejects points that are not on the curve.
+	point := append([]byte{0x04}, append(xBytes, yBytes...)...)
+	if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+		return jwk{}, errors.Wrap(err, "invalid EC point")
+	}
+
+	pub := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(xBytes),
+		Y:     new(big.Int).SetBytes(yBytes),
+	}
+
+	return jwk{Alg: "ES256", Key: pub}, nil
+}
@@ -0,0 +1,240 @@
+package external
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"math/big"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseRSAKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := b64(big.NewInt(int64(key.E)).Bytes())
+
+	tests := []struct {
+		name    string
+		n       string
+		e       string
+		wantErr bool
+	}{
+		{name: "valid", n: b64(key.N.Bytes()), e: e},
+		{name: "modulus too short", n: b64(weak.N.Bytes()), e: e, wantErr: true},
+		{name: "exponent too small", n: b64(key.N.Bytes()), e: b64([]byte{1}), wantErr: true},
+		{name: "invalid modulus encoding", n: "not base64!", e: e, wantErr: true},
+		{name: "invalid exponent encoding", n: b64(key.N.Bytes()), e: "not base64!", wantErr: true},
+		{name: "empty", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRSAKey(tt.n, tt.e)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRSAKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Alg != "RS256" {
+				t.Errorf("Alg = %q, want RS256", got.Alg)
+			}
+			if !key.PublicKey.Equal(got.Key) {
+				t.Error("parsed key does not match the original")
+			}
+		})
+	}
+}
+
+func TestParseECKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x := b64(key.X.FillBytes(make([]byte, 32)))
+	y := b64(key.Y.FillBytes(make([]byte, 32)))
+
+	// Moving y off the curve must be caught before the key is used.
+	offCurve := new(big.Int).Add(key.Y, big.NewInt(1))
+
+	tests := []struct {
+		name    string
+		crv     string
+		x       string
+		y       string
+		wantErr bool
+	}{
+		{name: "valid", crv: "P-256", x: x, y: y},
+		{name: "unsupported curve", crv: "P-384", x: x, y: y, wantErr: true},
+		{name: "point not on curve", crv: "P-256", x: x, y: b64(offCurve.FillBytes(make([]byte, 32))), wantErr: true},
+		{name: "short x", crv: "P-256", x: b64(make([]byte, 31)), y: y, wantErr: true},
+		{name: "short y", crv: "P-256", x: x, y: b64(make([]byte, 31)), wantErr: true},
+		{name: "invalid x encoding", crv: "P-256", x: "not base64!", y: y, wantErr: true},
+		{name: "zero point", crv: "P-256", x: b64(make([]byte, 32)), y: b64(make([]byte, 32)), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseECKey(tt.crv, tt.x, tt.y)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseECKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Alg != "ES256" {
+				t.Errorf("Alg = %q, want ES256", got.Alg)
+			}
+			if !key.PublicKey.Equal(got.Key) {
+				t.Error("parsed key does not match the original")
+			}
+		})
+	}
+}
+
+func TestJWKSKeySetLoad(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encryption := rsaJWK("enc", &rsaKey.PublicKey)
+	encryption["use"] = "enc"
+
+	mislabelled := ecJWK("mislabelled", &ecKey.PublicKey)
+	mislabelled["alg"] = "RS256"
+
+	broken := rsaJWK("broken", &rsaKey.PublicKey)
+	broken["n"] = "not base64!"
+
+	tests := []struct {
+		name     string
+		document any
+		wantKids []string
+		wantErr  bool
+	}{
+		{
+			name: "signing keys",
+			document: map[string]any{"keys": []map[string]string{
+				rsaJWK("rsa", &rsaKey.PublicKey),
+				ecJWK("ec", &ecKey.PublicKey),
+			}},
+			wantKids: []string{"ec", "rsa"},
+		},
+		{
+			name: "unusable keys are skipped",
+			document: map[string]any{"keys": []map[string]string{
+				rsaJWK("rsa", &rsaKey.PublicKey),
+				encryption,
+				mislabelled,
+				broken,
+				{"kty": "oct", "kid": "secret", "k": b64([]byte("secret"))},
+			}},
+			wantKids: []string{"rsa"},
+		},
+		{
+			name:     "no keys",
+			document: map[string]any{"keys": []map[string]string{}},
+			wantKids: []string{},
+		},
+		{
+			name:     "not a JWKS",
+			document: "keys",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &JWKSKeySet{
+				Source: writeJWKS(t, tt.document),
+				Logger: discardLogger(),
+			}
+
+			keys, err := s.load(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			kids := make([]string, 0, len(keys))
+			for kid := range keys {
+				kids = append(kids, kid)
+			}
+			sort.Strings(kids)
+
+			if len(kids) != len(tt.wantKids) {
+				t.Fatalf("kids = %v, want %v", kids, tt.wantKids)
+			}
+			for i := range kids {
+				if kids[i] != tt.wantKids[i] {
+					t.Fatalf("kids = %v, want %v", kids, tt.wantKids)
+				}
+			}
+		})
+	}
+}
+
+func TestJWKSKeySetKeyPicksUpRotatedKeys(t *testing.T) {
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := writeJWKS(t, map[string]any{"keys": []map[string]string{rsaJWK("first", &first.PublicKey)}})
+
+	s := &JWKSKeySet{
+		Source:          path,
+		RefreshInterval: time.Hour,
+		Logger:          discardLogger(),
+	}
+
+	if _, err := s.Key(context.Background(), "first"); err != nil {
+		t.Fatalf("Key(first) error = %v", err)
+	}
+
+	_, err = s.Key(context.Background(), "second")
+	if !errors.Is(err, ErrVerifierUnavailable) {
+		t.Fatalf("Key(second) error = %v, want ErrVerifierUnavailable", err)
+	}
+
+	rotated := writeJWKS(t, map[string]any{"keys": []map[string]string{rsaJWK("second", &second.PublicKey)}})
+	raw, err := os.ReadFile(rotated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Key(context.Background(), "second"); err != nil {
+		t.Fatalf("Key(second) after rotation error = %v", err)
+	}
+}
@@ -0,0 +1,297 @@
+package external
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type testKeys struct {
+	rsa  *rsa.PrivateKey
+	ec   *ecdsa.PrivateKey
+	jwks string
+}
+
+// newTestKeys writes a JWKS with an RSA key "rsa-1" and an EC key "ec-1".
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := map[string]any{
+		"keys": []map[string]string{
+			rsaJWK("rsa-1", &rsaKey.PublicKey),
+			ecJWK("ec-1", &ecKey.PublicKey),
+		},
+	}
+
+	return testKeys{rsa: rsaKey, ec: ecKey, jwks: writeJWKS(t, doc)}
+}
+
+func rsaJWK(kid string, pub *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"alg": "RS256",
+		"n":   b64(pub.N.Bytes()),
+		"e":   b64(big.NewInt(int64(pub.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, pub *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   b64(pub.X.FillBytes(make([]byte, 32))),
+		"y":   b64(pub.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func writeJWKS(t *testing.T, doc any) string {
+	t.Helper()
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func b64(raw []byte) string {
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func segment(t *testing.T, value any) string {
+	t.Helper()
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b64(raw)
+}
+
+// signToken builds a JWT whose signature is produced by sign over the
+// signing input.
+func signToken(t *testing.T, header, claims map[string]any, sign func(digest []byte) []byte) string {
+	t.Helper()
+
+	input := segment(t, header) + "." + segment(t, claims)
+	digest := sha256.Sum256([]byte(input))
+
+	return input + "." + b64(sign(digest[:]))
+}
+
+func TestJWTVerifierValidateToken(t *testing.T) {
+	keys := newTestKeys(t)
+
+	signRSA := func(digest []byte) []byte {
+		signature, err := rsa.SignPKCS1v15(rand.Reader, keys.rsa, crypto.SHA256, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signature
+	}
+	signEC := func(digest []byte) []byte {
+		r, s, err := ecdsa.Sign(rand.Reader, keys.ec, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	// signHS256 uses the RSA public key as the HMAC secret, the classic
+	// algorithm confusion attack.
+	signHS256 := func(digest []byte) []byte {
+		mac := hmac.New(sha256.New, keys.rsa.PublicKey.N.Bytes())
+		mac.Write(digest)
+		return mac.Sum(nil)
+	}
+	unsigned := func([]byte) []byte { return nil }
+
+	now := time.Now()
+	claims := func(overrides map[string]any) map[string]any {
+		res := map[string]any{
+			"sub":  "42",
+			"name": "reader",
+			"role": "User",
+			"iss":  "auth",
+			"aud":  []string{"library"},
+			"exp":  now.Add(time.Hour).Unix(),
+		}
+		for name, value := range overrides {
+			if value == nil {
+				delete(res, name)
+				continue
+			}
+			res[name] = value
+		}
+		return res
+	}
+
+	rs256 := map[string]any{"alg": "RS256", "kid": "rsa-1", "typ": "JWT"}
+	es256 := map[string]any{"alg": "ES256", "kid": "ec-1", "typ": "JWT"}
+
+	tampered := strings.Split(signToken(t, rs256, claims(nil), signRSA), ".")
+	tampered[1] = segment(t, claims(map[string]any{"role": "Admin"}))
+
+	tests := []struct {
+		name            string
+		token           string
+		wantUserID      string
+		wantErr         bool
+		wantUnavailable bool
+	}{
+		{
+			name:       "valid RS256",
+			token:      signToken(t, rs256, claims(nil), signRSA),
+			wantUserID: "42",
+		},
+		{
+			name:       "valid ES256",
+			token:      signToken(t, es256, claims(nil), signEC),
+			wantUserID: "42",
+		},
+		{
+			name:    "alg none",
+			token:   signToken(t, map[string]any{"alg": "none", "kid": "rsa-1"}, claims(nil), unsigned),
+			wantErr: true,
+		},
+		{
+			name:    "HS256 keyed with the public key",
+			token:   signToken(t, map[string]any{"alg": "HS256", "kid": "rsa-1"}, claims(nil), signHS256),
+			wantErr: true,
+		},
+		{
+			name:    "algorithm does not match key",
+			token:   signToken(t, map[string]any{"alg": "RS256", "kid": "ec-1"}, claims(nil), signRSA),
+			wantErr: true,
+		},
+		{
+			name:    "signed by another key",
+			token:   signToken(t, map[string]any{"alg": "ES256", "kid": "ec-1"}, claims(nil), signRSA),
+			wantErr: true,
+		},
+		{
+			name:    "tampered claims",
+			token:   strings.Join(tampered, "."),
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			token:   signToken(t, rs256, claims(map[string]any{"exp": now.Add(-time.Hour).Unix()}), signRSA),
+			wantErr: true,
+		},
+		{
+			name:       "expired within leeway",
+			token:      signToken(t, rs256, claims(map[string]any{"exp": now.Add(-10 * time.Second).Unix()}), signRSA),
+			wantUserID: "42",
+		},
+		{
+			name:    "no exp",
+			token:   signToken(t, rs256, claims(map[string]any{"exp": nil}), signRSA),
+			wantErr: true,
+		},
+		{
+			name:    "not valid yet",
+			token:   signToken(t, rs256, claims(map[string]any{"nbf": now.Add(time.Hour).Unix()}), signRSA),
+			wantErr: true,
+		},
+		{
+			name:    "untrusted issuer",
+			token:   signToken(t, rs256, claims(map[string]any{"iss": "elsewhere"}), signRSA),
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			token:   signToken(t, rs256, claims(map[string]any{"aud": "billing"}), signRSA),
+			wantErr: true,
+		},
+		{
+			name:    "no user id",
+			token:   signToken(t, rs256, claims(map[string]any{"sub": nil}), signRSA),
+			wantErr: true,
+		},
+		{
+			name:            "unknown kid",
+			token:           signToken(t, map[string]any{"alg": "RS256", "kid": "rsa-2"}, claims(nil), signRSA),
+			wantErr:         true,
+			wantUnavailable: true,
+		},
+		{
+			name:            "opaque token",
+			token:           "opaque-token",
+			wantErr:         true,
+			wantUnavailable: true,
+		},
+		{
+			name:            "undecodable header",
+			token:           "!!.e30.e30",
+			wantErr:         true,
+			wantUnavailable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &JWTVerifier{
+				Keys: &JWKSKeySet{
+					Source:          keys.jwks,
+					RefreshInterval: time.Hour,
+					Logger:          discardLogger(),
+				},
+				Claims: JWTClaims{
+					UserID:   "sub",
+					Username: "name",
+					Role:     "role",
+				},
+				Issuer:   "auth",
+				Audience: "library",
+				Leeway:   time.Minute,
+				Logger:   discardLogger(),
+			}
+
+			res, err := v.ValidateToken(context.Background(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrVerifierUnavailable); got != tt.wantUnavailable {
+				t.Errorf("errors.Is(err, ErrVerifierUnavailable) = %v, want %v (err: %v)", got, tt.wantUnavailable, err)
+			}
+			if res.UserID != tt.wantUserID {
+				t.Errorf("UserID = %q, want %q", res.UserID, tt.wantUserID)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package helpers
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -33,3 +34,12 @@ func GetEnvInt(key string, defaultValue int) int {
 	}
 	return value
 }
+
+func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	valueStr := GetEnv(key, defaultValue.String())
+	value, err := time.ParseDuration(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
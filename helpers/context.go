@@ -0,0 +1,20 @@
+package helpers
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+)
+
+type tokenDataKey struct{}
+
+// ContextWithTokenData stores the authenticated caller so that services can
+// read it from a plain context.Context.
+func ContextWithTokenData(ctx context.Context, tokenData models.TokenData) context.Context {
+	return context.WithValue(ctx, tokenDataKey{}, tokenData)
+}
+
+func TokenDataFromContext(ctx context.Context) (models.TokenData, bool) {
+	tokenData, ok := ctx.Value(tokenDataKey{}).(models.TokenData)
+	return tokenData, ok
+}
@@ -15,17 +15,22 @@ var (
 	RedisCtx    = context.Background()
 )
 
-func SetupPostgres() {
-	var err error
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Jakarta",
+// PostgresDSN builds the connection string shared by the pool and by
+// dedicated LISTEN connections.
+func PostgresDSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Jakarta",
 		GetEnv("DB_HOST", "127.0.0.1"),
 		GetEnv("DB_USER", ""),
 		GetEnv("DB_PASSWORD", ""),
 		GetEnv("DB_NAME", ""),
 		GetEnv("DB_PORT", "5432"),
 	)
+}
+
+func SetupPostgres() {
+	var err error
 
-	DB, err = sqlx.Connect("postgres", dsn)
+	DB, err = sqlx.Connect("postgres", PostgresDSN())
 	if err != nil {
 		Logger.Fatal("failed to connect to database: ", err)
 	}
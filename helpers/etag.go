@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatETag renders a row version as a strong entity tag.
+func FormatETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// ParseETag reads the version out of a strong entity tag produced by
+// FormatETag. Weak tags and wildcards are rejected since they cannot guard a
+// write against a concurrent one.
+func ParseETag(etag string) (int, bool) {
+	etag = strings.TrimSpace(etag)
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return 0, false
+	}
+
+	// Only the digits FormatETag writes; Atoi alone would also take a sign.
+	digits := etag[1 : len(etag)-1]
+	if strings.TrimLeft(digits, "0123456789") != "" {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(digits)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+
+	return version, true
+}
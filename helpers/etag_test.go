@@ -0,0 +1,46 @@
+package helpers
+
+import "testing"
+
+func TestFormatETag(t *testing.T) {
+	if got := FormatETag(7); got != `"7"` {
+		t.Fatalf("FormatETag(7) = %s, want \"7\"", got)
+	}
+}
+
+func TestParseETag(t *testing.T) {
+	tests := []struct {
+		name    string
+		etag    string
+		version int
+		ok      bool
+	}{
+		{name: "strong tag", etag: `"3"`, version: 3, ok: true},
+		{name: "surrounding whitespace", etag: ` "12" `, version: 12, ok: true},
+		{name: "round trip", etag: FormatETag(42), version: 42, ok: true},
+		{name: "empty", etag: ``},
+		{name: "wildcard", etag: `*`},
+		{name: "weak tag", etag: `W/"3"`},
+		{name: "unquoted", etag: `3`},
+		{name: "single quote", etag: `"`},
+		{name: "empty quotes", etag: `""`},
+		{name: "missing closing quote", etag: `"3`},
+		{name: "list of tags", etag: `"1", "2"`},
+		{name: "zero", etag: `"0"`},
+		{name: "negative", etag: `"-1"`},
+		{name: "plus sign", etag: `"+1"`},
+		{name: "not a number", etag: `"abc"`},
+		{name: "hex", etag: `"0x10"`},
+		{name: "inner whitespace", etag: `" 3"`},
+		{name: "overflow", etag: `"99999999999999999999"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, ok := ParseETag(tt.etag)
+			if ok != tt.ok || version != tt.version {
+				t.Fatalf("ParseETag(%q) = %d, %t; want %d, %t", tt.etag, version, ok, tt.version, tt.ok)
+			}
+		})
+	}
+}
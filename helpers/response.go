@@ -2,6 +2,11 @@ package helpers
 
 type Response map[string]any
 
+type fieldErrors interface {
+	error
+	FieldErrors() map[string][]string
+}
+
 func Success(data any, message string) Response {
 	msg := "Your request has been successfully processed"
 	if message != "" {
@@ -31,11 +36,15 @@ func Error(errorMsg any) Response {
 		}
 	}
 
-	if errHttp, ok := errorMsg.(*CustomError); ok {
+	if errField, ok := errorMsg.(fieldErrors); ok {
+		errs := errField.FieldErrors()
+		if errs == nil {
+			errs = make(map[string][]string)
+		}
 		return Response{
-			"errors":  errHttp.Errors,
+			"errors":  errs,
 			"success": false,
-			"message": errHttp.Msg,
+			"message": errField.Error(),
 		}
 	}
 
@@ -1,21 +1,43 @@
 package author
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hilmiikhsan/library-author-service/constants"
 	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
 	"github.com/hilmiikhsan/library-author-service/internal/dto"
 	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
 	"github.com/hilmiikhsan/library-author-service/internal/validator"
 )
 
+// sseRetryMillis tells browsers how long to wait before reconnecting.
+const sseRetryMillis = 3000
+
 type AuthorHandler struct {
-	AuthorService interfaces.IAuthorService
-	Validator     *validator.Validator
+	AuthorService    interfaces.IAuthorService
+	ImportJobService interfaces.IImportJobService
+	Policy           interfaces.IPolicy
+	Validator        *validator.Validator
+
+	// EventHeartbeat and EventWriteTimeout tune the SSE change stream.
+	EventHeartbeat    time.Duration
+	EventWriteTimeout time.Duration
+
+	// ImportMaxBytes and ImportMaxRows bound a single import upload.
+	ImportMaxBytes int64
+	ImportMaxRows  int
 }
 
 func (api *AuthorHandler) CreateAuthor(ctx *gin.Context) {
@@ -31,31 +53,19 @@ func (api *AuthorHandler) CreateAuthor(ctx *gin.Context) {
 
 	if err := api.Validator.Validate(req); err != nil {
 		helpers.Logger.Error("handler::CreateAuthor - Failed to validate request : ", err)
-		code, errs := helpers.Errors(err, req)
-		ctx.JSON(code, helpers.Error(errs))
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
 		return
 	}
 
-	err := api.AuthorService.CreateAuthor(ctx.Request.Context(), req)
+	res, err := api.AuthorService.CreateAuthor(ctx.Request.Context(), req)
 	if err != nil {
-		if strings.Contains(err.Error(), constants.ErrInvalidFormatDate) {
-			helpers.Logger.Error("handler::CreateAuthor - Invalid format date")
-			ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrInvalidFormatDate))
-			return
-		}
-
-		if strings.Contains(err.Error(), constants.ErrAuthorAlreadyExist) {
-			helpers.Logger.Error("handler::CreateAuthor - Author already exist")
-			ctx.JSON(http.StatusConflict, helpers.Error(constants.ErrAuthorAlreadyExist))
-			return
-		}
-
 		helpers.Logger.Error("handler::CreateAuthor - Failed to create Author : ", err)
-		ctx.JSON(http.StatusInternalServerError, helpers.Error(err.Error()))
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, helpers.Success(nil, ""))
+	ctx.Header("ETag", helpers.FormatETag(res.Version))
+	ctx.JSON(http.StatusCreated, helpers.Success(res, ""))
 }
 
 func (api *AuthorHandler) GetDetailAuthor(ctx *gin.Context) {
@@ -77,14 +87,44 @@ func (api *AuthorHandler) GetDetailAuthor(ctx *gin.Context) {
 
 	res, err := api.AuthorService.GetDetailAuthor(ctx.Request.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), constants.ErrAuthorNotFound) {
-			helpers.Logger.Error("handler::GetDetailAuthor - Author not found")
-			ctx.JSON(http.StatusNotFound, helpers.Error(constants.ErrAuthorNotFound))
-			return
-		}
-
 		helpers.Logger.Error("handler::GetDetailAuthor - Failed to get Author detail : ", err)
-		ctx.JSON(http.StatusInternalServerError, helpers.Error(err.Error()))
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.Header("ETag", helpers.FormatETag(res.Version))
+
+	// The author was merged into res.ID; point clients at its new location.
+	if !strings.EqualFold(res.ID, id) {
+		ctx.Header("Location", "/author/v1/"+res.ID)
+		ctx.JSON(http.StatusMovedPermanently, helpers.Success(res, ""))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
+
+func (api *AuthorHandler) BatchGetAuthors(ctx *gin.Context) {
+	var (
+		req = new(dto.BatchGetAuthorsRequest)
+	)
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helpers.Logger.Error("handler::BatchGetAuthors - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error("handler::BatchGetAuthors - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	res, err := api.AuthorService.BatchGetAuthors(ctx.Request.Context(), req.IDs)
+	if err != nil {
+		helpers.Logger.Error("handler::BatchGetAuthors - Failed to get Author batch : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
 		return
 	}
 
@@ -92,24 +132,76 @@ func (api *AuthorHandler) GetDetailAuthor(ctx *gin.Context) {
 }
 
 func (api *AuthorHandler) GetListAuthor(ctx *gin.Context) {
-	pageIndexStr := ctx.Query("page")
-	pageSizeStr := ctx.Query("limit")
+	var (
+		req = new(dto.GetListAuthorRequest)
+	)
+
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		helpers.Logger.Error("handler::GetListAuthor - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error("handler::GetListAuthor - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
 
-	pageIndex, _ := strconv.Atoi(pageIndexStr)
-	pageSize, _ := strconv.Atoi(pageSizeStr)
+	if req.IncludeDeleted && !api.can(ctx, constants.PermissionAuthorReadDeleted) {
+		helpers.Logger.Error("handler::GetListAuthor - include_deleted requires author:read_deleted permission")
+		err := domain.Forbidden(constants.ErrAuthRolePermission)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
 
-	if pageIndex <= 0 {
-		pageIndex = 1
+	if req.Page <= 0 {
+		req.Page = 1
 	}
 
-	if pageSize <= 0 {
-		pageSize = 10
+	if req.Limit <= 0 {
+		req.Limit = 10
 	}
 
-	res, err := api.AuthorService.GetListAuthor(ctx.Request.Context(), pageSize, pageIndex)
+	res, err := api.AuthorService.GetListAuthor(ctx.Request.Context(), req)
 	if err != nil {
 		helpers.Logger.Error("handler::GetListAuthor - Failed to get list Author : ", err)
-		ctx.JSON(http.StatusInternalServerError, helpers.Error(err.Error()))
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
+
+func (api *AuthorHandler) SearchAuthors(ctx *gin.Context) {
+	var (
+		req = new(dto.SearchAuthorRequest)
+	)
+
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		helpers.Logger.Error("handler::SearchAuthors - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error("handler::SearchAuthors - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	res, err := api.AuthorService.SearchAuthors(ctx.Request.Context(), req)
+	if err != nil {
+		helpers.Logger.Error("handler::SearchAuthors - Failed to search Author : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
 		return
 	}
 
@@ -129,8 +221,7 @@ func (api *AuthorHandler) UpdateAuthor(ctx *gin.Context) {
 
 	if err := api.Validator.Validate(req); err != nil {
 		helpers.Logger.Error("handler::UpdateAuthor - Failed to validate request : ", err)
-		code, errs := helpers.Errors(err, req)
-		ctx.JSON(code, helpers.Error(errs))
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
 		return
 	}
 
@@ -140,28 +231,89 @@ func (api *AuthorHandler) UpdateAuthor(ctx *gin.Context) {
 		return
 	}
 
-	err := api.AuthorService.UpdateAuthor(ctx.Request.Context(), req)
+	expectedVersion, err := parseIfMatch(ctx)
 	if err != nil {
-		if strings.Contains(err.Error(), constants.ErrInvalidFormatDate) {
-			helpers.Logger.Error("handler::CreateAuthor - Invalid format date")
-			ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrInvalidFormatDate))
-			return
-		}
-
-		if strings.Contains(err.Error(), constants.ErrAuthorNotFound) {
-			helpers.Logger.Error("handler::UpdateAuthor - Author not found")
-			ctx.JSON(http.StatusNotFound, helpers.Error(constants.ErrAuthorNotFound))
-			return
-		}
+		helpers.Logger.Error("handler::UpdateAuthor - Invalid If-Match header : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+	req.ExpectedVersion = expectedVersion
 
+	err = api.AuthorService.UpdateAuthor(ctx.Request.Context(), req)
+	if err != nil {
 		helpers.Logger.Error("handler::UpdateAuthor - Failed to update Author : ", err)
-		ctx.JSON(http.StatusInternalServerError, helpers.Error(err.Error()))
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
 		return
 	}
 
 	ctx.JSON(http.StatusOK, helpers.Success(nil, ""))
 }
 
+func (api *AuthorHandler) PatchAuthor(ctx *gin.Context) {
+	var (
+		id  = ctx.Param("id")
+		req = new(dto.PatchAuthorRequest)
+	)
+
+	if !helpers.IsValidUUID(id) {
+		helpers.Logger.Error("handler::PatchAuthor - Invalid UUID format for parameter: id")
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrIdIsNotValidUUID))
+		return
+	}
+
+	body, err := ctx.GetRawData()
+	if err != nil {
+		helpers.Logger.Error("handler::PatchAuthor - Failed to read request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	// A merge patch must be an object; its keys tell which fields to touch.
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		helpers.Logger.Error("handler::PatchAuthor - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrInvalidMergePatch))
+		return
+	}
+
+	if err := json.Unmarshal(body, req); err != nil {
+		helpers.Logger.Error("handler::PatchAuthor - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	for field := range patch {
+		req.Fields = append(req.Fields, field)
+	}
+	sort.Strings(req.Fields)
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error("handler::PatchAuthor - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(ctx)
+	if err != nil {
+		helpers.Logger.Error("handler::PatchAuthor - Invalid If-Match header : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	req.ID = id
+	req.ExpectedVersion = expectedVersion
+
+	res, err := api.AuthorService.PatchAuthor(ctx.Request.Context(), req)
+	if err != nil {
+		helpers.Logger.Error("handler::PatchAuthor - Failed to patch Author : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.Header("ETag", helpers.FormatETag(res.Version))
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
+
 func (api *AuthorHandler) DeleteAuthor(ctx *gin.Context) {
 	var (
 		id = ctx.Param("id")
@@ -179,18 +331,287 @@ func (api *AuthorHandler) DeleteAuthor(ctx *gin.Context) {
 		return
 	}
 
-	err := api.AuthorService.DeleteAuthor(ctx.Request.Context(), id)
+	expectedVersion, err := parseIfMatch(ctx)
 	if err != nil {
-		if strings.Contains(err.Error(), constants.ErrAuthorNotFound) {
-			helpers.Logger.Error("handler::DeleteAuthor - Author not found")
-			ctx.JSON(http.StatusNotFound, helpers.Error(constants.ErrAuthorNotFound))
-			return
-		}
+		helpers.Logger.Error("handler::DeleteAuthor - Invalid If-Match header : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
 
+	err = api.AuthorService.DeleteAuthor(ctx.Request.Context(), id, expectedVersion)
+	if err != nil {
 		helpers.Logger.Error("handler::DeleteAuthor - Failed to delete Author : ", err)
-		ctx.JSON(http.StatusInternalServerError, helpers.Error(err.Error()))
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(nil, ""))
+}
+
+func (api *AuthorHandler) RestoreAuthor(ctx *gin.Context) {
+	var (
+		id = ctx.Param("id")
+	)
+
+	if !helpers.IsValidUUID(id) {
+		helpers.Logger.Error("handler::RestoreAuthor - Invalid UUID format for parameter: id")
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrIdIsNotValidUUID))
+		return
+	}
+
+	err := api.AuthorService.RestoreAuthor(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.Logger.Error("handler::RestoreAuthor - Failed to restore Author : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
 		return
 	}
 
 	ctx.JSON(http.StatusOK, helpers.Success(nil, ""))
 }
+
+func (api *AuthorHandler) GetAuthorHistory(ctx *gin.Context) {
+	var (
+		req = new(dto.GetAuthorHistoryRequest)
+	)
+
+	if err := ctx.ShouldBindUri(req); err != nil {
+		helpers.Logger.Error("handler::GetAuthorHistory - Failed to bind uri : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		helpers.Logger.Error("handler::GetAuthorHistory - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error("handler::GetAuthorHistory - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	res, err := api.AuthorService.GetAuthorHistory(ctx.Request.Context(), req)
+	if err != nil {
+		helpers.Logger.Error("handler::GetAuthorHistory - Failed to get Author history : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
+
+func (api *AuthorHandler) GetAuthorVersion(ctx *gin.Context) {
+	var (
+		id = ctx.Param("id")
+	)
+
+	if !helpers.IsValidUUID(id) {
+		helpers.Logger.Error("handler::GetAuthorVersion - Invalid UUID format for parameter: id")
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrIdIsNotValidUUID))
+		return
+	}
+
+	version, err := parseVersionParam(ctx)
+	if err != nil {
+		helpers.Logger.Error("handler::GetAuthorVersion - Invalid parameter: version")
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	res, err := api.AuthorService.GetAuthorVersion(ctx.Request.Context(), id, version)
+	if err != nil {
+		helpers.Logger.Error("handler::GetAuthorVersion - Failed to get Author version : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
+
+func (api *AuthorHandler) RevertAuthor(ctx *gin.Context) {
+	var (
+		id = ctx.Param("id")
+	)
+
+	if !helpers.IsValidUUID(id) {
+		helpers.Logger.Error("handler::RevertAuthor - Invalid UUID format for parameter: id")
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrIdIsNotValidUUID))
+		return
+	}
+
+	version, err := parseVersionParam(ctx)
+	if err != nil {
+		helpers.Logger.Error("handler::RevertAuthor - Invalid parameter: version")
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(ctx)
+	if err != nil {
+		helpers.Logger.Error("handler::RevertAuthor - Invalid If-Match header : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	res, err := api.AuthorService.RevertAuthor(ctx.Request.Context(), id, version, expectedVersion)
+	if err != nil {
+		helpers.Logger.Error("handler::RevertAuthor - Failed to revert Author : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.Header("ETag", helpers.FormatETag(res.Version))
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
+
+// StreamAuthorEvents serves the author change feed as Server-Sent Events.
+// Browsers resume through Last-Event-ID; events are read from the outbox at
+// the client's pace, and a client that stops reading for EventWriteTimeout
+// is disconnected instead of being buffered for.
+func (api *AuthorHandler) StreamAuthorEvents(ctx *gin.Context) {
+	var (
+		req = new(dto.WatchAuthorsRequest)
+	)
+
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		helpers.Logger.Error("handler::StreamAuthorEvents - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if lastEventID := ctx.GetHeader("Last-Event-ID"); lastEventID != "" {
+		req.ResumeToken = lastEventID
+	}
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error("handler::StreamAuthorEvents - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	var (
+		mu         sync.Mutex
+		controller = http.NewResponseController(ctx.Writer)
+		streamCtx  = ctx.Request.Context()
+	)
+
+	write := func(frame string) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err := controller.SetWriteDeadline(time.Now().Add(api.EventWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+
+		if _, err := io.WriteString(ctx.Writer, frame); err != nil {
+			return err
+		}
+
+		return controller.Flush()
+	}
+
+	// Clear the deadline so a reused keep-alive connection is not affected.
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		controller.SetWriteDeadline(time.Time{})
+	}()
+
+	if err := write(fmt.Sprintf("retry: %d\n\n", sseRetryMillis)); err != nil {
+		helpers.Logger.Error("handler::StreamAuthorEvents - Failed to open stream : ", err)
+		return
+	}
+
+	streamCtx, cancel := context.WithCancel(streamCtx)
+
+	// gin reuses the writer once the handler returns, so the heartbeat must
+	// have stopped by then.
+	heartbeatDone := make(chan struct{})
+	defer func() {
+		cancel()
+		<-heartbeatDone
+	}()
+
+	go func() {
+		defer close(heartbeatDone)
+
+		ticker := time.NewTicker(api.EventHeartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-streamCtx.Done():
+				return
+			case <-ticker.C:
+				if err := write(": heartbeat\n\n"); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	err := api.AuthorService.WatchAuthorEvents(streamCtx, req, func(event dto.AuthorEvent) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		return write(fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.EventType, data))
+	})
+	if err != nil {
+		helpers.Logger.Error("handler::StreamAuthorEvents - Failed to stream Author events : ", err)
+		if domain.HTTPStatus(err) != http.StatusInternalServerError {
+			publicErr := domain.Public(err)
+			_ = write(fmt.Sprintf("event: error\ndata: %s\n\n", publicErr.Msg))
+		}
+	}
+}
+
+func parseVersionParam(ctx *gin.Context) (int, error) {
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil || version <= 0 {
+		return 0, domain.Validation(constants.ErrInvalidVersion).WithField("version", constants.ErrInvalidVersion)
+	}
+
+	return version, nil
+}
+
+// parseIfMatch returns the author version the client last saw, taken from
+// the ETag it echoes back in If-Match.
+func parseIfMatch(ctx *gin.Context) (int, error) {
+	ifMatch := ctx.GetHeader("If-Match")
+	if ifMatch == "" {
+		return 0, domain.PreconditionRequired(constants.ErrIfMatchRequired)
+	}
+
+	version, ok := helpers.ParseETag(ifMatch)
+	if !ok {
+		return 0, domain.PreconditionFailed(constants.ErrInvalidIfMatch)
+	}
+
+	return version, nil
+}
+
+// can reports whether the caller's role is granted permission.
+func (api *AuthorHandler) can(ctx *gin.Context, permission string) bool {
+	tokenData, ok := helpers.TokenDataFromContext(ctx.Request.Context())
+	return ok && api.Policy.Allows(tokenData.Role, permission)
+}
@@ -0,0 +1,284 @@
+package author
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+)
+
+// ExportAuthors streams every author matching the list filters as CSV,
+// JSONL or MARC 21 authority XML. Nothing is written until the first author
+// is read, so request errors still get a JSON response; an error after that
+// truncates the download.
+func (api *AuthorHandler) ExportAuthors(ctx *gin.Context) {
+	var (
+		req = new(dto.ExportAuthorsRequest)
+	)
+
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		helpers.Logger.Error("handler::ExportAuthors - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error("handler::ExportAuthors - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	if req.IncludeDeleted && !api.can(ctx, constants.PermissionAuthorReadDeleted) {
+		helpers.Logger.Error("handler::ExportAuthors - include_deleted requires author:read_deleted permission")
+		err := domain.Forbidden(constants.ErrAuthRolePermission)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	var (
+		buf      = bufio.NewWriter(ctx.Writer)
+		exporter = newAuthorExporter(req.Format, buf)
+		started  bool
+	)
+
+	start := func() error {
+		started = true
+
+		ctx.Header("Content-Type", exporter.ContentType())
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="authors-%s.%s"`, time.Now().Format("20060102"), req.Format))
+		ctx.Status(http.StatusOK)
+
+		return exporter.Begin()
+	}
+
+	err := api.AuthorService.ExportAuthors(ctx.Request.Context(), req, func(author dto.Author) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+
+		return exporter.Write(author)
+	})
+	if err != nil && !started {
+		helpers.Logger.Error("handler::ExportAuthors - Failed to export Author : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+	if err != nil {
+		helpers.Logger.Error("handler::ExportAuthors - Export interrupted : ", err)
+		return
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			helpers.Logger.Error("handler::ExportAuthors - Failed to write export : ", err)
+			return
+		}
+	}
+
+	if err := exporter.End(); err != nil {
+		helpers.Logger.Error("handler::ExportAuthors - Failed to write export : ", err)
+		return
+	}
+
+	if err := buf.Flush(); err != nil {
+		helpers.Logger.Error("handler::ExportAuthors - Failed to flush export : ", err)
+	}
+}
+
+type authorExporter interface {
+	ContentType() string
+	Begin() error
+	Write(author dto.Author) error
+	End() error
+}
+
+func newAuthorExporter(format string, w io.Writer) authorExporter {
+	switch format {
+	case "jsonl":
+		return &jsonlAuthorExporter{encoder: json.NewEncoder(w)}
+	case "xml":
+		return &marcAuthorExporter{w: w, encoder: xml.NewEncoder(w)}
+	default:
+		return &csvAuthorExporter{writer: csv.NewWriter(w)}
+	}
+}
+
+type csvAuthorExporter struct {
+	writer *csv.Writer
+}
+
+func (e *csvAuthorExporter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (e *csvAuthorExporter) Begin() error {
+	return e.writer.Write([]string{"id", "name", "bio", "birth_date", "death_date", "version", "created_at", "updated_at", "deleted_at"})
+}
+
+func (e *csvAuthorExporter) Write(author dto.Author) error {
+	return e.writer.Write([]string{
+		author.ID,
+		author.Name,
+		author.Bio,
+		author.BirthDate,
+		author.DeathDate,
+		strconv.Itoa(author.Version),
+		author.CreatedAt,
+		author.UpdatedAt,
+		author.DeletedAt,
+	})
+}
+
+func (e *csvAuthorExporter) End() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type jsonlAuthorExporter struct {
+	encoder *json.Encoder
+}
+
+func (e *jsonlAuthorExporter) ContentType() string {
+	return "application/x-ndjson"
+}
+
+func (e *jsonlAuthorExporter) Begin() error {
+	return nil
+}
+
+func (e *jsonlAuthorExporter) Write(author dto.Author) error {
+	return e.encoder.Encode(author)
+}
+
+func (e *jsonlAuthorExporter) End() error {
+	return nil
+}
+
+const (
+	marcNamespace = "http://www.loc.gov/MARC21/slim"
+
+	// marcLeader describes a new (n) or deleted (d) Unicode authority
+	// record (z); record length and base address are left zero.
+	marcLeaderNew     = "00000nz  a2200000n  4500"
+	marcLeaderDeleted = "00000dz  a2200000n  4500"
+
+	// marcTimestampFormat is the layout of control field 005.
+	marcTimestampFormat = "20060102150405.0"
+)
+
+type marcRecord struct {
+	XMLName       xml.Name           `xml:"record"`
+	Type          string             `xml:"type,attr"`
+	Leader        string             `xml:"leader"`
+	ControlFields []marcControlField `xml:"controlfield"`
+	DataFields    []marcDataField    `xml:"datafield"`
+}
+
+type marcControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type marcDataField struct {
+	Tag       string         `xml:"tag,attr"`
+	Ind1      string         `xml:"ind1,attr"`
+	Ind2      string         `xml:"ind2,attr"`
+	Subfields []marcSubfield `xml:"subfield"`
+}
+
+type marcSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// marcAuthorExporter writes a MARCXML collection of authority records. The
+// author is the 100 heading, with life dates in 046 and the bio in 678.
+type marcAuthorExporter struct {
+	w       io.Writer
+	encoder *xml.Encoder
+}
+
+func (e *marcAuthorExporter) ContentType() string {
+	return "application/marcxml+xml; charset=utf-8"
+}
+
+func (e *marcAuthorExporter) Begin() error {
+	_, err := io.WriteString(e.w, xml.Header+`<collection xmlns="`+marcNamespace+`">`+"\n")
+	return err
+}
+
+func (e *marcAuthorExporter) Write(author dto.Author) error {
+	record := marcRecord{
+		Type:   "Authority",
+		Leader: marcLeaderNew,
+		ControlFields: []marcControlField{
+			{Tag: "001", Value: author.ID},
+		},
+	}
+
+	if author.DeletedAt != "" {
+		record.Leader = marcLeaderDeleted
+	}
+
+	if updatedAt, err := time.Parse(constants.TimestampFormat, author.UpdatedAt); err == nil {
+		record.ControlFields = append(record.ControlFields, marcControlField{Tag: "005", Value: updatedAt.UTC().Format(marcTimestampFormat)})
+	}
+
+	lifeDates := []marcSubfield{{Code: "f", Value: strings.ReplaceAll(author.BirthDate, "-", "")}}
+	if author.DeathDate != "" {
+		lifeDates = append(lifeDates, marcSubfield{Code: "g", Value: strings.ReplaceAll(author.DeathDate, "-", "")})
+	}
+
+	record.DataFields = append(record.DataFields,
+		marcDataField{Tag: "046", Ind1: " ", Ind2: " ", Subfields: lifeDates},
+		marcDataField{Tag: "100", Ind1: "0", Ind2: " ", Subfields: []marcSubfield{
+			{Code: "a", Value: author.Name},
+			{Code: "d", Value: marcLifeSpan(author.BirthDate, author.DeathDate)},
+		}},
+	)
+
+	if author.Bio != "" {
+		record.DataFields = append(record.DataFields, marcDataField{Tag: "678", Ind1: " ", Ind2: " ", Subfields: []marcSubfield{
+			{Code: "a", Value: author.Bio},
+		}})
+	}
+
+	if err := e.encoder.Encode(record); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(e.w, "\n")
+	return err
+}
+
+func (e *marcAuthorExporter) End() error {
+	_, err := io.WriteString(e.w, "</collection>\n")
+	return err
+}
+
+// marcLifeSpan renders the 100 $d dates as "1920-1990", or "1920-" for a
+// living author.
+func marcLifeSpan(birthDate, deathDate string) string {
+	year := func(date string) string {
+		if len(date) < 4 {
+			return ""
+		}
+		return date[:4]
+	}
+
+	return year(birthDate) + "-" + year(deathDate)
+}
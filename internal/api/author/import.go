@@ -0,0 +1,211 @@
+package author
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+)
+
+const (
+	importFormatCSV   = "csv"
+	importFormatJSONL = "jsonl"
+
+	// maxJSONLLineSize bounds a single JSONL record.
+	maxJSONLLineSize = 1 << 20
+)
+
+var importRequiredColumns = []string{"name", "bio", "birth_date"}
+
+// ImportAuthors accepts a multipart "file" upload in CSV (with a header row)
+// or JSONL and queues it as an import job. The format comes from the "format"
+// query parameter or the file extension. Rows are parsed and validated here;
+// the job reports progress and the rows that were not imported.
+func (api *AuthorHandler) ImportAuthors(ctx *gin.Context) {
+	dryRun, _ := strconv.ParseBool(ctx.Query("dry_run"))
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, api.ImportMaxBytes)
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		helpers.Logger.Error("handler::ImportAuthors - Failed to read file : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrImportFileRequired))
+		return
+	}
+
+	format := importFormat(ctx.Query("format"), fileHeader.Filename)
+	if format == "" {
+		helpers.Logger.Error("handler::ImportAuthors - Unsupported import format")
+		err := domain.Validation(constants.ErrImportFormat).WithField("format", constants.ErrImportFormat)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		helpers.Logger.Error("handler::ImportAuthors - Failed to open file : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrImportFileRequired))
+		return
+	}
+	defer file.Close()
+
+	var rows []dto.ImportAuthorRow
+	if format == importFormatCSV {
+		rows, err = api.readImportCSV(file)
+	} else {
+		rows, err = api.readImportJSONL(file)
+	}
+	if err != nil {
+		helpers.Logger.Error("handler::ImportAuthors - Failed to parse file : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	for i := range rows {
+		if rows[i].Errors != nil {
+			continue
+		}
+
+		if err := api.Validator.Validate(rows[i]); err != nil {
+			rows[i].Errors = domain.Public(err).FieldErrors()
+		}
+	}
+
+	res, err := api.ImportJobService.CreateImportJob(ctx.Request.Context(), &dto.CreateImportJobRequest{
+		FileName: filepath.Base(fileHeader.Filename),
+		Format:   format,
+		DryRun:   dryRun,
+		Rows:     rows,
+	})
+	if err != nil {
+		helpers.Logger.Error("handler::ImportAuthors - Failed to create import job : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.Header("Location", "/author/v1/jobs/"+res.ID)
+	ctx.JSON(http.StatusAccepted, helpers.Success(res, ""))
+}
+
+func (api *AuthorHandler) readImportCSV(file io.Reader) ([]dto.ImportAuthorRow, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, domain.Validation(constants.ErrImportMissingColumn).WithCause(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		columns[column] = i
+	}
+
+	for _, column := range importRequiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, domain.Validation(constants.ErrImportMissingColumn).WithField(column, constants.ErrImportMissingColumn)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]dto.ImportAuthorRow, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if len(rows) >= api.ImportMaxRows {
+			return nil, domain.Validation(constants.ErrImportTooManyRows)
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, dto.ImportAuthorRow{
+				Line:   parseErr.Line,
+				Errors: map[string][]string{"row": {parseErr.Err.Error()}},
+			})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, dto.ImportAuthorRow{
+			Line:      line,
+			Name:      field(record, "name"),
+			Bio:       field(record, "bio"),
+			BirthDate: field(record, "birth_date"),
+			DeathDate: field(record, "death_date"),
+		})
+	}
+
+	return rows, nil
+}
+
+func (api *AuthorHandler) readImportJSONL(file io.Reader) ([]dto.ImportAuthorRow, error) {
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLineSize)
+
+	rows := make([]dto.ImportAuthorRow, 0)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		if len(rows) >= api.ImportMaxRows {
+			return nil, domain.Validation(constants.ErrImportTooManyRows)
+		}
+
+		row := dto.ImportAuthorRow{}
+		if err := json.Unmarshal([]byte(text), &row); err != nil {
+			row = dto.ImportAuthorRow{Errors: map[string][]string{"row": {err.Error()}}}
+		}
+		row.Line = line
+
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func importFormat(format, filename string) string {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	}
+
+	switch strings.ToLower(format) {
+	case "csv":
+		return importFormatCSV
+	case "jsonl", "ndjson":
+		return importFormatJSONL
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,74 @@
+package author
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+)
+
+func (api *AuthorHandler) GetDuplicateCandidates(ctx *gin.Context) {
+	var (
+		req = new(dto.GetDuplicateCandidatesRequest)
+	)
+
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		helpers.Logger.Error("handler::GetDuplicateCandidates - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error("handler::GetDuplicateCandidates - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	res, err := api.AuthorService.GetDuplicateCandidates(ctx.Request.Context(), req)
+	if err != nil {
+		helpers.Logger.Error("handler::GetDuplicateCandidates - Failed to get duplicate candidates : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
+
+func (api *AuthorHandler) MergeAuthors(ctx *gin.Context) {
+	var (
+		req = new(dto.MergeAuthorsRequest)
+	)
+
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		helpers.Logger.Error("handler::MergeAuthors - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error("handler::MergeAuthors - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	res, err := api.AuthorService.MergeAuthors(ctx.Request.Context(), req)
+	if err != nil {
+		helpers.Logger.Error("handler::MergeAuthors - Failed to merge Author : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.Header("ETag", helpers.FormatETag(res.Version))
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
@@ -14,12 +14,12 @@ type Healthcheck struct {
 }
 
 func (api *Healthcheck) HealthcheckHandlerHTTP(c *gin.Context) {
-	msg, err := api.HealthcheckServices.HealthcheckServices()
+	res, msg, err := api.HealthcheckServices.HealthcheckServices()
 	if err != nil {
 		log.Error("healthcheck::HealthcheckHandlerHTTP - failed to get healthcheck services: ", err)
 		c.JSON(http.StatusInternalServerError, nil)
 		return
 	}
 
-	c.JSON(http.StatusOK, helpers.Success(nil, msg))
+	c.JSON(http.StatusOK, helpers.Success(res, msg))
 }
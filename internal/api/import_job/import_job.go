@@ -0,0 +1,123 @@
+package importjob
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+)
+
+type ImportJobHandler struct {
+	ImportJobService interfaces.IImportJobService
+}
+
+func (api *ImportJobHandler) GetImportJob(ctx *gin.Context) {
+	var (
+		id = ctx.Param("id")
+	)
+
+	if !helpers.IsValidUUID(id) {
+		helpers.Logger.Error("handler::GetImportJob - Invalid UUID format for parameter: id")
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrIdIsNotValidUUID))
+		return
+	}
+
+	res, err := api.ImportJobService.GetImportJob(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.Logger.Error("handler::GetImportJob - Failed to get import job : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
+
+func (api *ImportJobHandler) CancelImportJob(ctx *gin.Context) {
+	var (
+		id = ctx.Param("id")
+	)
+
+	if !helpers.IsValidUUID(id) {
+		helpers.Logger.Error("handler::CancelImportJob - Invalid UUID format for parameter: id")
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrIdIsNotValidUUID))
+		return
+	}
+
+	res, err := api.ImportJobService.CancelImportJob(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.Logger.Error("handler::CancelImportJob - Failed to cancel import job : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
+
+// DownloadImportJobErrors serves the rows of the job that were not imported
+// as a CSV file. It can be fetched while the job is still running.
+func (api *ImportJobHandler) DownloadImportJobErrors(ctx *gin.Context) {
+	var (
+		id = ctx.Param("id")
+	)
+
+	if !helpers.IsValidUUID(id) {
+		helpers.Logger.Error("handler::DownloadImportJobErrors - Invalid UUID format for parameter: id")
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrIdIsNotValidUUID))
+		return
+	}
+
+	rows, err := api.ImportJobService.GetImportJobErrors(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.Logger.Error("handler::DownloadImportJobErrors - Failed to get import job errors : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="import-%s-errors.csv"`, id))
+	ctx.Status(http.StatusOK)
+
+	writer := csv.NewWriter(ctx.Writer)
+	_ = writer.Write([]string{"line", "name", "status", "reason", "errors"})
+	for _, row := range rows {
+		_ = writer.Write([]string{
+			strconv.Itoa(row.Line),
+			row.Name,
+			row.Status,
+			row.Reason,
+			formatFieldErrors(row.Errors),
+		})
+	}
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		helpers.Logger.Error("handler::DownloadImportJobErrors - Failed to write error file : ", err)
+	}
+}
+
+// formatFieldErrors flattens field errors into "field: message; ..." in
+// field order.
+func formatFieldErrors(errs map[string][]string) string {
+	fields := make([]string, 0, len(errs))
+	for field := range errs {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, 0, len(errs))
+	for _, field := range fields {
+		for _, msg := range errs[field] {
+			parts = append(parts, field+": "+msg)
+		}
+	}
+
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,180 @@
+package webhook
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+	"github.com/hilmiikhsan/library-author-service/internal/validator"
+)
+
+type WebhookHandler struct {
+	WebhookService interfaces.IWebhookService
+	Validator      *validator.Validator
+}
+
+func (api *WebhookHandler) CreateWebhook(ctx *gin.Context) {
+	var (
+		req = new(dto.CreateWebhookRequest)
+	)
+
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		helpers.Logger.Error("handler::CreateWebhook - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error("handler::CreateWebhook - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	res, err := api.WebhookService.CreateWebhook(ctx.Request.Context(), req)
+	if err != nil {
+		helpers.Logger.Error("handler::CreateWebhook - Failed to create Webhook : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.Success(res, ""))
+}
+
+func (api *WebhookHandler) GetListWebhook(ctx *gin.Context) {
+	var (
+		req = new(dto.GetListWebhookRequest)
+	)
+
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		helpers.Logger.Error("handler::GetListWebhook - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error("handler::GetListWebhook - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	res, err := api.WebhookService.GetListWebhook(ctx.Request.Context(), req)
+	if err != nil {
+		helpers.Logger.Error("handler::GetListWebhook - Failed to get list Webhook : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
+
+func (api *WebhookHandler) DeleteWebhook(ctx *gin.Context) {
+	var (
+		id = ctx.Param("id")
+	)
+
+	if !helpers.IsValidUUID(id) {
+		helpers.Logger.Error("handler::DeleteWebhook - Invalid UUID format for parameter: id")
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrIdIsNotValidUUID))
+		return
+	}
+
+	err := api.WebhookService.DeleteWebhook(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.Logger.Error("handler::DeleteWebhook - Failed to delete Webhook : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(nil, ""))
+}
+
+func (api *WebhookHandler) GetWebhookDeliveries(ctx *gin.Context) {
+	var (
+		id = ctx.Param("id")
+	)
+
+	if !helpers.IsValidUUID(id) {
+		helpers.Logger.Error("handler::GetWebhookDeliveries - Invalid UUID format for parameter: id")
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrIdIsNotValidUUID))
+		return
+	}
+
+	api.getDeliveries(ctx, "handler::GetWebhookDeliveries", id, "")
+}
+
+// GetDeadLetters lists deliveries that used up their retries, across every
+// subscription.
+func (api *WebhookHandler) GetDeadLetters(ctx *gin.Context) {
+	api.getDeliveries(ctx, "handler::GetDeadLetters", "", constants.WebhookStatusDead)
+}
+
+func (api *WebhookHandler) RetryWebhookDelivery(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		helpers.Logger.Error("handler::RetryWebhookDelivery - Invalid parameter: id")
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	err = api.WebhookService.RetryWebhookDelivery(ctx.Request.Context(), id)
+	if err != nil {
+		helpers.Logger.Error("handler::RetryWebhookDelivery - Failed to retry Webhook delivery : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(nil, ""))
+}
+
+func (api *WebhookHandler) getDeliveries(ctx *gin.Context, logPrefix, subscriptionID, status string) {
+	var (
+		req = new(dto.GetWebhookDeliveriesRequest)
+	)
+
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		helpers.Logger.Error(logPrefix+" - Failed to bind request : ", err)
+		ctx.JSON(http.StatusBadRequest, helpers.Error(constants.ErrFailedBadRequest))
+		return
+	}
+
+	if err := api.Validator.Validate(req); err != nil {
+		helpers.Logger.Error(logPrefix+" - Failed to validate request : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	req.SubscriptionID = subscriptionID
+	if status != "" {
+		req.Status = status
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	res, err := api.WebhookService.GetWebhookDeliveries(ctx.Request.Context(), req)
+	if err != nil {
+		helpers.Logger.Error(logPrefix+" - Failed to get Webhook deliveries : ", err)
+		ctx.JSON(domain.HTTPStatus(err), helpers.Error(domain.Public(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.Success(res, ""))
+}
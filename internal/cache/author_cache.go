@@ -0,0 +1,164 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// AuthorCache caches single authors under per-ID keys and list pages under
+// keys namespaced by a generation counter. Every write bumps the generation,
+// so stale list pages are never read again and simply expire.
+type AuthorCache struct {
+	Redis  *redis.Client
+	Logger *logrus.Logger
+	Prefix string
+	TTL    time.Duration
+}
+
+func (c *AuthorCache) AuthorKey(id string) string {
+	return fmt.Sprintf("%s:author:%s", c.Prefix, id)
+}
+
+// ListKey namespaces key with the current list generation. Resolve it once
+// per read so a page loaded before a write is stored under the old
+// generation and never served afterwards.
+func (c *AuthorCache) ListKey(ctx context.Context, key string) string {
+	return fmt.Sprintf("%s:authors:g%d:%s", c.Prefix, c.generation(ctx), key)
+}
+
+// Get decodes the value stored under key into dest and reports whether it
+// was found.
+func (c *AuthorCache) Get(ctx context.Context, key string, dest any) bool {
+	cachedData, err := c.Redis.Get(ctx, key).Result()
+	if err != nil {
+		if err != redis.Nil {
+			c.Logger.Warn("cache::Get - Failed to get cache data: ", err)
+		}
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(cachedData), dest); err != nil {
+		c.Logger.Warn("cache::Get - Failed to unmarshal cache data: ", err)
+		return false
+	}
+
+	return true
+}
+
+func (c *AuthorCache) Set(ctx context.Context, key string, value any) {
+	dataToCache, err := json.Marshal(value)
+	if err != nil {
+		c.Logger.Warn("cache::Set - Failed to marshal data for caching: ", err)
+		return
+	}
+
+	if err := c.Redis.Set(ctx, key, dataToCache, c.TTL).Err(); err != nil {
+		c.Logger.Warn("cache::Set - Failed to cache data: ", err)
+	}
+}
+
+// GetAuthors returns the cached authors for ids and the ids that missed.
+func (c *AuthorCache) GetAuthors(ctx context.Context, ids []string) ([]models.Author, []string) {
+	var (
+		res     = make([]models.Author, 0, len(ids))
+		missIDs = make([]string, 0)
+		keys    = make([]string, 0, len(ids))
+	)
+
+	if len(ids) == 0 {
+		return res, missIDs
+	}
+
+	for _, id := range ids {
+		keys = append(keys, c.AuthorKey(id))
+	}
+
+	cachedData, err := c.Redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		c.Logger.Warn("cache::GetAuthors - Failed to get cache data: ", err)
+		return res, ids
+	}
+
+	for i, data := range cachedData {
+		str, ok := data.(string)
+		if !ok {
+			missIDs = append(missIDs, ids[i])
+			continue
+		}
+
+		var author models.Author
+		if err := json.Unmarshal([]byte(str), &author); err != nil {
+			c.Logger.Warn("cache::GetAuthors - Failed to unmarshal cache data: ", err)
+			missIDs = append(missIDs, ids[i])
+			continue
+		}
+
+		res = append(res, author)
+	}
+
+	return res, missIDs
+}
+
+func (c *AuthorCache) SetAuthors(ctx context.Context, authors ...models.Author) {
+	if len(authors) == 0 {
+		return
+	}
+
+	pipe := c.Redis.Pipeline()
+	for _, author := range authors {
+		dataToCache, err := json.Marshal(author)
+		if err != nil {
+			c.Logger.Warn("cache::SetAuthors - Failed to marshal data for caching: ", err)
+			continue
+		}
+		pipe.Set(ctx, c.AuthorKey(author.ID.String()), dataToCache, c.TTL)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.Logger.Warn("cache::SetAuthors - Failed to cache data: ", err)
+	}
+}
+
+// InvalidateAuthor drops the cached author and every cached list page.
+func (c *AuthorCache) InvalidateAuthor(ctx context.Context, id string) {
+	if err := c.Redis.Del(ctx, c.AuthorKey(id)).Err(); err != nil {
+		c.Logger.Warn("cache::InvalidateAuthor - Failed to delete cache data: ", err)
+	}
+
+	c.InvalidateList(ctx)
+}
+
+// InvalidateList moves list reads to a new generation.
+func (c *AuthorCache) InvalidateList(ctx context.Context) {
+	if err := c.Redis.Incr(ctx, c.generationKey()).Err(); err != nil {
+		c.Logger.Warn("cache::InvalidateList - Failed to bump cache generation: ", err)
+	}
+}
+
+func (c *AuthorCache) generation(ctx context.Context) int64 {
+	gen, err := c.Redis.Get(ctx, c.generationKey()).Result()
+	if err != nil {
+		if err != redis.Nil {
+			c.Logger.Warn("cache::generation - Failed to get cache generation: ", err)
+		}
+		return 0
+	}
+
+	value, err := strconv.ParseInt(gen, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return value
+}
+
+func (c *AuthorCache) generationKey() string {
+	return fmt.Sprintf("%s:authors:generation", c.Prefix)
+}
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+// The scripts only touch KEYS[1] while it still holds the caller's token.
+var (
+	releaseLockScript = redis.NewScript(`
+		if redis.call("GET", KEYS[1]) == ARGV[1] then
+			return redis.call("DEL", KEYS[1])
+		end
+		return 0
+	`)
+
+	refreshLockScript = redis.NewScript(`
+		if redis.call("GET", KEYS[1]) == ARGV[1] then
+			return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+		end
+		return 0
+	`)
+)
+
+// RedisLocker hands out expiring locks so that a piece of work is done by a
+// single replica at a time. A lock is owned by the random token returned on
+// acquire and can only be refreshed or released with it.
+type RedisLocker struct {
+	Redis  *redis.Client
+	Logger *logrus.Logger
+	Prefix string
+}
+
+func (l *RedisLocker) Acquire(ctx context.Context, name string, ttl time.Duration) (string, bool, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", false, err
+	}
+	token := hex.EncodeToString(buf)
+
+	ok, err := l.Redis.SetNX(ctx, l.key(name), token, ttl).Result()
+	if err != nil {
+		l.Logger.Error("cache::Acquire - Failed to acquire lock: ", err)
+		return "", false, err
+	}
+
+	return token, ok, nil
+}
+
+// Refresh extends a held lock and reports whether it was still held.
+func (l *RedisLocker) Refresh(ctx context.Context, name, token string, ttl time.Duration) (bool, error) {
+	res, err := refreshLockScript.Run(ctx, l.Redis, []string{l.key(name)}, token, ttl.Milliseconds()).Int()
+	if err != nil {
+		l.Logger.Error("cache::Refresh - Failed to refresh lock: ", err)
+		return false, err
+	}
+
+	return res == 1, nil
+}
+
+func (l *RedisLocker) Release(ctx context.Context, name, token string) {
+	if err := releaseLockScript.Run(ctx, l.Redis, []string{l.key(name)}, token).Err(); err != nil {
+		l.Logger.Warn("cache::Release - Failed to release lock: ", err)
+	}
+}
+
+func (l *RedisLocker) key(name string) string {
+	return fmt.Sprintf("%s:lock:%s", l.Prefix, name)
+}
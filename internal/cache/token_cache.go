@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// TokenCache remembers tokens the auth service accepted. Keys hold a SHA-256
+// of the token, never the token itself. Entries live for TTL but never past
+// the token's own expiry, so a revoked token can be accepted for at most TTL.
+type TokenCache struct {
+	Redis  *redis.Client
+	Logger *logrus.Logger
+	Prefix string
+	TTL    time.Duration
+}
+
+func (c *TokenCache) Get(ctx context.Context, token string) (models.TokenData, bool) {
+	var (
+		res models.TokenData
+	)
+
+	cachedData, err := c.Redis.Get(ctx, c.key(token)).Result()
+	if err != nil {
+		if err != redis.Nil {
+			c.Logger.Warn("cache::Get - Failed to get cached token: ", err)
+		}
+		return res, false
+	}
+
+	if err := json.Unmarshal([]byte(cachedData), &res); err != nil {
+		c.Logger.Warn("cache::Get - Failed to unmarshal cached token: ", err)
+		return res, false
+	}
+
+	return res, true
+}
+
+// Set caches data for token. A zero expiresAt means the expiry is unknown
+// and only TTL applies.
+func (c *TokenCache) Set(ctx context.Context, token string, data models.TokenData, expiresAt time.Time) {
+	ttl := c.TTL
+	if !expiresAt.IsZero() {
+		ttl = min(ttl, time.Until(expiresAt))
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	dataToCache, err := json.Marshal(data)
+	if err != nil {
+		c.Logger.Warn("cache::Set - Failed to marshal token data: ", err)
+		return
+	}
+
+	if err := c.Redis.Set(ctx, c.key(token), dataToCache, ttl).Err(); err != nil {
+		c.Logger.Warn("cache::Set - Failed to cache token: ", err)
+	}
+}
+
+func (c *TokenCache) key(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return fmt.Sprintf("%s:token:%s", c.Prefix, hex.EncodeToString(sum[:]))
+}
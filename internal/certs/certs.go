@@ -0,0 +1,241 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// reloader holds a value loaded from files and loads it again once any of
+// them has a new modification time. Files are checked at most once per
+// interval; while a reload fails the last good value is kept, so a rotation
+// caught half-written is picked up on a later check.
+type reloader[T any] struct {
+	files    []string
+	interval time.Duration
+	load     func() (T, error)
+	logger   *logrus.Logger
+
+	mu        sync.Mutex
+	value     T
+	loaded    bool
+	modTimes  []time.Time
+	checkedAt time.Time
+}
+
+func newReloader[T any](files []string, interval time.Duration, logger *logrus.Logger, load func() (T, error)) (*reloader[T], error) {
+	r := &reloader[T]{
+		files:    files,
+		interval: interval,
+		load:     load,
+		logger:   logger,
+	}
+
+	// Fail at startup rather than on the first handshake.
+	if _, err := r.get(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *reloader[T]) get() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.loaded && time.Since(r.checkedAt) < r.interval {
+		return r.value, nil
+	}
+	r.checkedAt = time.Now()
+
+	modTimes, err := r.stat()
+	if err == nil && r.loaded && equalTimes(modTimes, r.modTimes) {
+		return r.value, nil
+	}
+
+	if err == nil {
+		var value T
+		value, err = r.load()
+		if err == nil {
+			r.value, r.loaded, r.modTimes = value, true, modTimes
+			r.logger.Infof("loaded TLS material from %v", r.files)
+			return r.value, nil
+		}
+	}
+
+	if !r.loaded {
+		return r.value, err
+	}
+
+	r.logger.Warn("certs::get - keeping previous TLS material: ", err)
+	return r.value, nil
+}
+
+func (r *reloader[T]) stat() ([]time.Time, error) {
+	res := make([]time.Time, 0, len(r.files))
+	for _, file := range r.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, info.ModTime())
+	}
+
+	return res, nil
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// KeyPair is a certificate and private key that follow the files on disk.
+type KeyPair struct {
+	reloader *reloader[*tls.Certificate]
+}
+
+func NewKeyPair(certFile, keyFile string, interval time.Duration, logger *logrus.Logger) (*KeyPair, error) {
+	r, err := newReloader([]string{certFile, keyFile}, interval, logger, func() (*tls.Certificate, error) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load key pair")
+		}
+		return &cert, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyPair{reloader: r}, nil
+}
+
+func (k *KeyPair) Certificate() (*tls.Certificate, error) {
+	return k.reloader.get()
+}
+
+// CAPool is a set of PEM CA certificates that follows the file on disk.
+type CAPool struct {
+	reloader *reloader[*x509.CertPool]
+}
+
+func NewCAPool(file string, interval time.Duration, logger *logrus.Logger) (*CAPool, error) {
+	r, err := newReloader([]string{file}, interval, logger, func() (*x509.CertPool, error) {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(raw) {
+			return nil, errors.Errorf("no CA certificates in %s", file)
+		}
+		return pool, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CAPool{reloader: r}, nil
+}
+
+func (c *CAPool) Pool() (*x509.CertPool, error) {
+	return c.reloader.get()
+}
+
+// ServerConfig serves keyPair and, when clientCAs is set, verifies client
+// certificates against it with clientAuth. Both are re-read per handshake.
+func ServerConfig(keyPair *KeyPair, clientCAs *CAPool, clientAuth tls.ClientAuthType) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, err := keyPair.Certificate()
+			if err != nil {
+				return nil, err
+			}
+
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				ClientAuth:   tls.NoClientCert,
+				NextProtos:   []string{"h2"},
+			}
+
+			if clientCAs != nil {
+				pool, err := clientCAs.Pool()
+				if err != nil {
+					return nil, err
+				}
+				config.ClientCAs = pool
+				config.ClientAuth = clientAuth
+			}
+
+			return config, nil
+		},
+	}
+}
+
+// ClientConfig verifies the server against rootCAs, or the system roots
+// when it is nil, and presents keyPair when the server asks for a client
+// certificate. serverName is the DNS name or IP address the server
+// certificate must carry.
+func ClientConfig(keyPair *KeyPair, rootCAs *CAPool, serverName string) *tls.Config {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+
+	if keyPair != nil {
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return keyPair.Certificate()
+		}
+	}
+
+	if rootCAs != nil {
+		// RootCAs is fixed once a config is in use, so the chain is verified
+		// by hand against the current pool. state.ServerName is empty for IP
+		// targets, so the name is checked against serverName instead, and
+		// without one the connection is refused rather than left unchecked.
+		config.InsecureSkipVerify = true
+		config.VerifyConnection = func(state tls.ConnectionState) error {
+			if serverName == "" {
+				return errors.New("no server name to verify the certificate against")
+			}
+
+			pool, err := rootCAs.Pool()
+			if err != nil {
+				return err
+			}
+
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("server presented no certificate")
+			}
+
+			intermediates := x509.NewCertPool()
+			for _, cert := range state.PeerCertificates[1:] {
+				intermediates.AddCert(cert)
+			}
+
+			_, err = state.PeerCertificates[0].Verify(x509.VerifyOptions{
+				DNSName:       serverName,
+				Roots:         pool,
+				Intermediates: intermediates,
+			})
+			return err
+		}
+	}
+
+	return config
+}
@@ -1,6 +1,7 @@
-package helpers
+package domain
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -8,17 +9,21 @@ import (
 	"github.com/lib/pq"
 )
 
-func errorPqHandler(errPq *pq.Error) (int, map[string][]string) {
+// FromDatabase translates PostgreSQL constraint violations into domain
+// errors. Any other error is returned unchanged.
+func FromDatabase(err error) error {
+	var errPq *pq.Error
+	if !errors.As(err, &errPq) {
+		return err
+	}
+
 	var (
-		errors    = make(map[string][]string)
-		code      = 500
+		kind      = ErrInternal
+		fields    = make(map[string][]string)
 		column    string
 		columnMsg string
 	)
 
-	Logger.Debug("pq error code name: ", errPq.Code.Name())
-	Logger.Debug("pq error detail: ", errPq.Detail)
-
 	if errPq.Code.Name() == "foreign_key_violation" {
 		regex := regexp.MustCompile(`Key \(([^)]+)\)`)
 		match := regex.FindStringSubmatch(errPq.Detail)
@@ -28,10 +33,10 @@ func errorPqHandler(errPq *pq.Error) (int, map[string][]string) {
 			columnMsg = strings.ReplaceAll(column, "_", " ")
 		}
 
-		errors[column] = append(errors[column], "invalid "+columnMsg+".")
-		code = 500
+		fields[column] = append(fields[column], "invalid "+columnMsg+".")
+		kind = ErrValidation
 	} else if errPq.Code.Name() == "unique_violation" {
-		code = 409
+		kind = ErrConflict
 		regex := regexp.MustCompile(`Key \(([^)]+)\)`)
 		match := regex.FindStringSubmatch(errPq.Detail)
 
@@ -44,14 +49,14 @@ func errorPqHandler(errPq *pq.Error) (int, map[string][]string) {
 			columns := strings.Join(sliceOfColumns, "_and_")
 			column = columns
 			columnMsg = "combination of " + strings.ReplaceAll(columns, "_", " ")
-			errors[column] = append(errors[column], fmt.Sprintf("%s already exists.", columnMsg))
+			fields[column] = append(fields[column], fmt.Sprintf("%s already exists.", columnMsg))
 		} else { // unique_violation is not compound key
 			columnMsg = strings.ReplaceAll(column, "_", " ")
 			msg := fmt.Sprintf("%s already exists.", columnMsg)
 			if column == "email" {
 				msg = "email already registered."
 			}
-			errors[column] = append(errors[column], msg)
+			fields[column] = append(fields[column], msg)
 		}
 	} else if errPq.Code.Name() == "not_null_violation" { // null value in column violates not-null constraint
 		// pq: null value in column "product_id" of relation "product_inquiries" violates not-null constraint
@@ -61,10 +66,21 @@ func errorPqHandler(errPq *pq.Error) (int, map[string][]string) {
 			column = matches[1]
 			// tableName := matches[2]
 			columnNameMsg := strings.ReplaceAll(column, "_", " ")
-			errors[column] = append(errors[column], fmt.Sprintf("%s tidak boleh kosong.", columnNameMsg))
-
+			fields[column] = append(fields[column], fmt.Sprintf("%s tidak boleh kosong.", columnNameMsg))
+			kind = ErrValidation
 		}
+	} else if errPq.Code.Name() == "invalid_text_representation" {
+		kind = ErrValidation
 	}
 
-	return code, errors
+	if kind == ErrInternal {
+		return err
+	}
+
+	return &Error{
+		Kind:   kind,
+		Msg:    defaultErrorMessage,
+		Fields: fields,
+		Err:    err,
+	}
 }
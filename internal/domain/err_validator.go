@@ -1,6 +1,7 @@
-package helpers
+package domain
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -8,13 +9,24 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
-func errorValidationHandler[T any](err error, payload *T) (int, map[string][]string) {
+// FromValidation translates validator output for payload into a domain
+// validation error with one message per failing field.
+func FromValidation(err error, payload any) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
 	var (
 		errorMessages = make(map[string][]string)
-		code          = 400
+		payloadType   = reflect.TypeOf(payload)
 	)
 
-	for _, err := range err.(validator.ValidationErrors) {
+	for payloadType != nil && payloadType.Kind() == reflect.Pointer {
+		payloadType = payloadType.Elem()
+	}
+
+	for _, err := range validationErrs {
 		var (
 			// Get the JSON tag name
 			namespace  = err.Namespace()               // ex: UpdateInterestRequest.interest
@@ -140,7 +152,10 @@ func errorValidationHandler[T any](err error, payload *T) (int, map[string][]str
 		case "eqfield":
 			eqField := err.Param()
 			eqFieldName := ""
-			eqFieldTag, _ := reflect.TypeOf(payload).Elem().FieldByName(eqField)
+			var eqFieldTag reflect.StructField
+			if payloadType != nil && payloadType.Kind() == reflect.Struct {
+				eqFieldTag, _ = payloadType.FieldByName(eqField)
+			}
 			eqFieldJSONTag := eqFieldTag.Tag.Get("json")
 			eqFieldQueryTag := eqFieldTag.Tag.Get("query")
 			eqFieldFormTag := eqFieldTag.Tag.Get("form")
@@ -179,5 +194,10 @@ func errorValidationHandler[T any](err error, payload *T) (int, map[string][]str
 		errorMessages[field] = append(errorMessages[field], message)
 	}
 
-	return code, errorMessages
+	return &Error{
+		Kind:   ErrValidation,
+		Msg:    defaultErrorMessage,
+		Fields: errorMessages,
+		Err:    err,
+	}
 }
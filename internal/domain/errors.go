@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sentinel kinds shared by every layer. Check them with errors.Is.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrValidation  = errors.New("validation failed")
+	ErrInvalidDate = errors.New("invalid date")
+	ErrForbidden   = errors.New("forbidden")
+	ErrInternal    = errors.New("internal error")
+
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrPreconditionRequired = errors.New("precondition required")
+)
+
+const defaultErrorMessage = "Your request has been failed to process"
+
+// Error is a domain error carrying its kind, a client facing message and
+// optional per-field messages.
+type Error struct {
+	Kind   error
+	Msg    string
+	Fields map[string][]string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+func (e *Error) Unwrap() []error {
+	errs := []error{e.Kind}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+func (e *Error) FieldErrors() map[string][]string {
+	return e.Fields
+}
+
+func (e *Error) WithField(field, msg string) *Error {
+	if e.Fields == nil {
+		e.Fields = make(map[string][]string)
+	}
+	e.Fields[field] = append(e.Fields[field], msg)
+	return e
+}
+
+func (e *Error) WithCause(err error) *Error {
+	e.Err = err
+	return e
+}
+
+func New(kind error, msg string) *Error {
+	return &Error{
+		Kind:   kind,
+		Msg:    msg,
+		Fields: make(map[string][]string),
+	}
+}
+
+func NotFound(msg string) *Error {
+	return New(ErrNotFound, msg)
+}
+
+func Conflict(msg string) *Error {
+	return New(ErrConflict, msg)
+}
+
+func Forbidden(msg string) *Error {
+	return New(ErrForbidden, msg)
+}
+
+func PreconditionFailed(msg string) *Error {
+	return New(ErrPreconditionFailed, msg)
+}
+
+func PreconditionRequired(msg string) *Error {
+	return New(ErrPreconditionRequired, msg)
+}
+
+func Validation(msg string) *Error {
+	return New(ErrValidation, msg)
+}
+
+func InvalidDate(field string) *Error {
+	return New(ErrInvalidDate, constants.ErrInvalidFormatDate).WithField(field, constants.ErrInvalidFormatDate)
+}
+
+func InvalidUUID(field string) *Error {
+	return Validation(constants.ErrIdIsNotValidUUID).WithField(field, constants.ErrIdIsNotValidUUID)
+}
+
+// Public returns the domain error wrapped in err, or a generic internal
+// error so that unexpected failures never leak to clients.
+func Public(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+
+	return New(ErrInternal, defaultErrorMessage).WithCause(err)
+}
+
+// HTTPStatus maps err onto the HTTP status code returned to clients.
+func HTTPStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, ErrPreconditionRequired):
+		return http.StatusPreconditionRequired
+	case errors.Is(err, ErrValidation), errors.Is(err, ErrInvalidDate):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GRPCCode maps err onto the gRPC status code returned to clients.
+func GRPCCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, ErrPreconditionFailed), errors.Is(err, ErrPreconditionRequired):
+		return codes.FailedPrecondition
+	case errors.Is(err, ErrValidation), errors.Is(err, ErrInvalidDate):
+		return codes.InvalidArgument
+	default:
+		return codes.Internal
+	}
+}
+
+// GRPCError translates err into a gRPC status error. Field messages are
+// attached as errdetails.BadRequest field violations.
+func GRPCError(err error) error {
+	publicErr := Public(err)
+	st := status.New(GRPCCode(err), publicErr.Msg)
+	if len(publicErr.Fields) == 0 {
+		return st.Err()
+	}
+
+	keys := make([]string, 0, len(publicErr.Fields))
+	for field := range publicErr.Fields {
+		keys = append(keys, field)
+	}
+	sort.Strings(keys)
+
+	badRequest := &errdetails.BadRequest{}
+	for _, field := range keys {
+		for _, description := range publicErr.Fields[field] {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: description,
+			})
+		}
+	}
+
+	stWithDetails, detailErr := st.WithDetails(badRequest)
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return stWithDetails.Err()
+}
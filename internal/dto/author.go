@@ -12,18 +12,96 @@ type UpdateAuthorRequest struct {
 	Bio       string `json:"bio" validate:"required,min=2,max=100"`
 	BirthDate string `json:"birth_date" validate:"required"`
 	DeathDate string `json:"death_date"`
+
+	ExpectedVersion int `json:"-"`
+}
+
+// PatchAuthorRequest is an RFC 7396 merge patch. Fields lists the keys
+// present in the patch; a listed field left nil was sent as null and is
+// cleared.
+type PatchAuthorRequest struct {
+	ID        string  `json:"-"`
+	Name      *string `json:"name" validate:"omitnil,min=2,max=100"`
+	Bio       *string `json:"bio" validate:"omitnil,min=2,max=100"`
+	BirthDate *string `json:"birth_date" validate:"omitnil,datetime=2006-01-02"`
+	DeathDate *string `json:"death_date" validate:"omitnil,datetime=2006-01-02"`
+
+	Fields          []string `json:"-"`
+	ExpectedVersion int      `json:"-"`
 }
 
 type GetDetailAuthorRequest struct {
 	ID string `json:"id" validate:"required"`
 }
 
+type BatchGetAuthorsRequest struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100,dive,uuid"`
+}
+
+type BatchGetAuthorsResponse struct {
+	AuthorList []Author `json:"author_list"`
+	MissingIDs []string `json:"missing_ids"`
+}
+
+type SearchAuthorRequest struct {
+	Query string `form:"q" validate:"required,min=2,max=100"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit" validate:"omitempty,max=100"`
+}
+
+type SearchAuthorResponse struct {
+	AuthorList []AuthorSearchResult `json:"author_list"`
+	Pagination Pagination           `json:"pagination"`
+}
+
+type AuthorSearchResult struct {
+	Author
+	Rank          float64 `json:"rank"`
+	NameHighlight string  `json:"name_highlight"`
+	BioSnippet    string  `json:"bio_snippet"`
+}
+
 type GetDetailAuthorResponse struct {
 	ID        string `json:"id"`
 	Name      string `json:"name"`
 	Bio       string `json:"bio"`
 	BirthDate string `json:"birth_date"`
 	DeathDate string `json:"death_date"`
+	Version   int    `json:"version"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type GetListAuthorRequest struct {
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit" validate:"omitempty,max=100"`
+	Name       string `form:"name" validate:"omitempty,max=100"`
+	BornAfter  string `form:"born_after" validate:"omitempty,datetime=2006-01-02"`
+	BornBefore string `form:"born_before" validate:"omitempty,datetime=2006-01-02"`
+	DiedAfter  string `form:"died_after" validate:"omitempty,datetime=2006-01-02"`
+	DiedBefore string `form:"died_before" validate:"omitempty,datetime=2006-01-02"`
+	IsLiving   *bool  `form:"is_living"`
+	Sort       string `form:"sort" validate:"omitempty,oneof=name birth_date created_at updated_at"`
+	Order      string `form:"order" validate:"omitempty,oneof=asc desc"`
+	Cursor     string `form:"cursor" validate:"omitempty,max=512"`
+
+	IncludeDeleted bool `form:"include_deleted"`
+}
+
+// ExportAuthorsRequest takes the filters and ordering of GetListAuthorRequest
+// without paging.
+type ExportAuthorsRequest struct {
+	Format     string `form:"format" validate:"required,oneof=csv jsonl xml"`
+	Name       string `form:"name" validate:"omitempty,max=100"`
+	BornAfter  string `form:"born_after" validate:"omitempty,datetime=2006-01-02"`
+	BornBefore string `form:"born_before" validate:"omitempty,datetime=2006-01-02"`
+	DiedAfter  string `form:"died_after" validate:"omitempty,datetime=2006-01-02"`
+	DiedBefore string `form:"died_before" validate:"omitempty,datetime=2006-01-02"`
+	IsLiving   *bool  `form:"is_living"`
+	Sort       string `form:"sort" validate:"omitempty,oneof=name birth_date created_at updated_at"`
+	Order      string `form:"order" validate:"omitempty,oneof=asc desc"`
+
+	IncludeDeleted bool `form:"include_deleted"`
 }
 
 type GetListAuthorResponse struct {
@@ -37,9 +115,153 @@ type Author struct {
 	Bio       string `json:"bio"`
 	BirthDate string `json:"birth_date"`
 	DeathDate string `json:"death_date"`
+	Version   int    `json:"version"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	DeletedAt string `json:"deleted_at,omitempty"`
+}
+
+type GetAuthorHistoryRequest struct {
+	ID    string `uri:"id" validate:"required,uuid"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit" validate:"omitempty,max=100"`
+}
+
+type GetAuthorHistoryResponse struct {
+	VersionList []AuthorVersion `json:"version_list"`
+	Pagination  Pagination      `json:"pagination"`
+}
+
+type AuthorVersion struct {
+	AuthorID          string `json:"author_id"`
+	Version           int    `json:"version"`
+	Operation         string `json:"operation"`
+	Name              string `json:"name"`
+	Bio               string `json:"bio"`
+	BirthDate         string `json:"birth_date"`
+	DeathDate         string `json:"death_date"`
+	DeletedAt         string `json:"deleted_at,omitempty"`
+	RevertedFrom      int    `json:"reverted_from,omitempty"`
+	ChangedBy         string `json:"changed_by"`
+	ChangedByUsername string `json:"changed_by_username"`
+	ChangedAt         string `json:"changed_at"`
+}
+
+type WatchAuthorsRequest struct {
+	ResumeToken string   `json:"resume_token" form:"last_event_id" validate:"omitempty,numeric,max=20"`
+	AuthorIDs   []string `json:"author_ids" form:"author_id" validate:"omitempty,max=100,dive,uuid"`
+}
+
+// AuthorEvent is one entry of the change feed. ID doubles as the resume
+// token: reconnecting with it replays every later event.
+type AuthorEvent struct {
+	ID         string `json:"id"`
+	EventType  string `json:"event_type"`
+	Author     Author `json:"author"`
+	MergedInto string `json:"merged_into,omitempty"`
+	OccurredAt string `json:"occurred_at"`
 }
 
 type Pagination struct {
-	Page  int `json:"page"`
-	Limit int `json:"limit"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	TotalItems int    `json:"total_items"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// ImportAuthorRow is one record of an import file. Line is its line number
+// in the upload and Errors holds what failed validation.
+type ImportAuthorRow struct {
+	Line      int    `json:"-"`
+	Name      string `json:"name" validate:"required,min=2,max=100"`
+	Bio       string `json:"bio" validate:"required,min=2,max=100"`
+	BirthDate string `json:"birth_date" validate:"required,datetime=2006-01-02"`
+	DeathDate string `json:"death_date" validate:"omitempty,datetime=2006-01-02"`
+
+	Errors map[string][]string `json:"-"`
+}
+
+type ImportAuthorsRequest struct {
+	Rows   []ImportAuthorRow
+	DryRun bool
+
+	// Seen carries the identities of earlier chunks of the same file so that
+	// duplicates are caught across calls. It is updated in place.
+	Seen map[string]struct{}
+}
+
+type ImportAuthorsResponse struct {
+	DryRun     bool                 `json:"dry_run"`
+	Total      int                  `json:"total"`
+	Created    int                  `json:"created"`
+	Duplicates int                  `json:"duplicates"`
+	Invalid    int                  `json:"invalid"`
+	Failed     int                  `json:"failed"`
+	Rows       []ImportAuthorResult `json:"rows"`
+}
+
+type ImportAuthorResult struct {
+	Line   int                 `json:"line"`
+	Status string              `json:"status"`
+	ID     string              `json:"id,omitempty"`
+	Name   string              `json:"name,omitempty"`
+	Reason string              `json:"reason,omitempty"`
+	Errors map[string][]string `json:"errors,omitempty"`
+}
+
+type CreateImportJobRequest struct {
+	FileName string
+	Format   string
+	DryRun   bool
+	Rows     []ImportAuthorRow
+}
+
+// ImportJob reports the progress of an asynchronous import. Dry-run jobs
+// create nothing and count the rows that would have been created in
+// ValidRows.
+type ImportJob struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	FileName      string `json:"file_name"`
+	Format        string `json:"format"`
+	DryRun        bool   `json:"dry_run"`
+	TotalRows     int    `json:"total_rows"`
+	ProcessedRows int    `json:"processed_rows"`
+	CreatedRows   int    `json:"created_rows"`
+	ValidRows     int    `json:"valid_rows"`
+	DuplicateRows int    `json:"duplicate_rows"`
+	InvalidRows   int    `json:"invalid_rows"`
+	FailedRows    int    `json:"failed_rows"`
+	ErrorMessage  string `json:"error_message,omitempty"`
+	CreatedBy     string `json:"created_by,omitempty"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	StartedAt     string `json:"started_at,omitempty"`
+	FinishedAt    string `json:"finished_at,omitempty"`
+}
+
+type GetDuplicateCandidatesRequest struct {
+	AuthorID string  `form:"author_id" validate:"omitempty,uuid"`
+	MinScore float64 `form:"min_score" validate:"omitempty,gt=0,lte=1"`
+	Page     int     `form:"page"`
+	Limit    int     `form:"limit" validate:"omitempty,max=100"`
+}
+
+type GetDuplicateCandidatesResponse struct {
+	CandidateList []DuplicateCandidate `json:"candidate_list"`
+	Pagination    Pagination           `json:"pagination"`
+}
+
+type DuplicateCandidate struct {
+	Author         Author  `json:"author"`
+	Duplicate      Author  `json:"duplicate"`
+	Score          float64 `json:"score"`
+	NameSimilarity float64 `json:"name_similarity"`
+}
+
+type MergeAuthorsRequest struct {
+	SourceID string `json:"source_id" validate:"required,uuid"`
+	TargetID string `json:"target_id" validate:"required,uuid"`
 }
@@ -0,0 +1,13 @@
+package dto
+
+type Healthcheck struct {
+	Status      string            `json:"status"`
+	AuthService AuthServiceHealth `json:"auth_service"`
+}
+
+// AuthServiceHealth reports the circuit breaker in front of the auth
+// service; Breaker is closed, open or half_open.
+type AuthServiceHealth struct {
+	Breaker  string `json:"breaker"`
+	Failures int    `json:"consecutive_failures"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	Secret     string   `json:"secret" validate:"required,min=16,max=256"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,unique,dive,oneof=author.created author.updated author.deleted author.restored author.merged"`
+}
+
+type GetListWebhookRequest struct {
+	Page  int `form:"page"`
+	Limit int `form:"limit" validate:"omitempty,max=100"`
+}
+
+type GetListWebhookResponse struct {
+	WebhookList []Webhook  `json:"webhook_list"`
+	Pagination  Pagination `json:"pagination"`
+}
+
+// Webhook never carries the secret back to clients.
+type Webhook struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	IsActive   bool     `json:"is_active"`
+	CreatedBy  string   `json:"created_by"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+type GetWebhookDeliveriesRequest struct {
+	SubscriptionID string `json:"-"`
+	Status         string `form:"status" validate:"omitempty,oneof=pending delivered dead"`
+	Page           int    `form:"page"`
+	Limit          int    `form:"limit" validate:"omitempty,max=100"`
+}
+
+type GetWebhookDeliveriesResponse struct {
+	DeliveryList []WebhookDelivery `json:"delivery_list"`
+	Pagination   Pagination        `json:"pagination"`
+}
+
+type WebhookDelivery struct {
+	ID             int64  `json:"id"`
+	SubscriptionID string `json:"subscription_id"`
+	EventType      string `json:"event_type"`
+	AuthorID       string `json:"author_id"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	LastStatusCode int    `json:"last_status_code,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+}
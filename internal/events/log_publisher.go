@@ -0,0 +1,26 @@
+package events
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// LogPublisher writes author events to the log. It is meant for local
+// development where no consumer is running.
+type LogPublisher struct {
+	Logger *logrus.Logger
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, event models.AuthorEvent) error {
+	p.Logger.WithFields(logrus.Fields{
+		"event_id":    event.ID,
+		"event_type":  event.EventType,
+		"author_id":   event.AuthorID.String(),
+		"occurred_at": event.CreatedAt.Format(constants.TimestampFormat),
+	}).Info("events::Publish - ", event.Payload.String())
+
+	return nil
+}
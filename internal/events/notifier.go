@@ -0,0 +1,80 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// PgNotifier wakes subscribers whenever Postgres sends a NOTIFY on Channel.
+// It also wakes them every PollInterval so notifications lost while the
+// listener reconnects are still picked up. Wake-ups are coalesced: a
+// subscriber is told that something changed, not what.
+type PgNotifier struct {
+	DSN          string
+	Channel      string
+	PollInterval time.Duration
+	Logger       *logrus.Logger
+
+	once        sync.Once
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+// Subscribe returns a wake-up channel and a function that releases it.
+func (n *PgNotifier) Subscribe() (<-chan struct{}, func()) {
+	n.once.Do(func() {
+		n.subscribers = make(map[chan struct{}]struct{})
+		go n.run()
+	})
+
+	ch := make(chan struct{}, 1)
+
+	n.mu.Lock()
+	n.subscribers[ch] = struct{}{}
+	n.mu.Unlock()
+
+	return ch, func() {
+		n.mu.Lock()
+		delete(n.subscribers, ch)
+		n.mu.Unlock()
+	}
+}
+
+func (n *PgNotifier) run() {
+	listener := pq.NewListener(n.DSN, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			n.Logger.Warn("events::PgNotifier - listener connection event: ", err)
+		}
+	})
+
+	if err := listener.Listen(n.Channel); err != nil {
+		n.Logger.Error("events::PgNotifier - failed to listen on channel: ", err)
+	}
+
+	ticker := time.NewTicker(n.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-listener.Notify:
+		case <-ticker.C:
+		}
+
+		n.broadcast()
+	}
+}
+
+func (n *PgNotifier) broadcast() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for ch := range n.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
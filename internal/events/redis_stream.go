@@ -0,0 +1,42 @@
+package events
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// RedisStreamPublisher appends author events to a single Redis stream, which
+// keeps them in the order the relay publishes them. Consumers should dedupe
+// on event_id since delivery is at-least-once.
+type RedisStreamPublisher struct {
+	Redis  *redis.Client
+	Logger *logrus.Logger
+	Stream string
+	MaxLen int64
+}
+
+func (p *RedisStreamPublisher) Publish(ctx context.Context, event models.AuthorEvent) error {
+	err := p.Redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.Stream,
+		MaxLen: p.MaxLen,
+		Approx: true,
+		Values: map[string]any{
+			"event_id":    strconv.FormatInt(event.ID, 10),
+			"event_type":  event.EventType,
+			"author_id":   event.AuthorID.String(),
+			"payload":     event.Payload.String(),
+			"occurred_at": event.CreatedAt.Format(constants.TimestampFormat),
+		},
+	}).Err()
+	if err != nil {
+		p.Logger.Error("events::Publish - failed to add event to stream: ", err)
+		return err
+	}
+
+	return nil
+}
@@ -2,11 +2,11 @@ package grpc
 
 import (
 	"context"
-	"strings"
 
 	"github.com/hilmiikhsan/library-author-service/cmd/proto/author"
 	"github.com/hilmiikhsan/library-author-service/constants"
 	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
 	"github.com/hilmiikhsan/library-author-service/internal/dto"
 	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
 	"github.com/hilmiikhsan/library-author-service/internal/validator"
@@ -25,34 +25,330 @@ func (api *AuthorAPI) GetDetailAuthor(ctx context.Context, req *author.AuthorReq
 
 	if err := api.Validator.Validate(internalReq); err != nil {
 		helpers.Logger.Error("api::GetDetailAuthor - Failed to validate request : ", err)
-		return &author.AuthorResponse{
-			Message: "Failed to validate request",
-			Data:    nil,
-		}, nil
+		return nil, domain.GRPCError(err)
+	}
+
+	if !helpers.IsValidUUID(internalReq.ID) {
+		helpers.Logger.Error("api::GetDetailAuthor - Invalid UUID format for parameter: id")
+		return nil, domain.GRPCError(domain.InvalidUUID("id"))
 	}
 
 	res, err := api.AuthorService.GetDetailAuthor(ctx, internalReq.ID)
 	if err != nil {
-		if strings.Contains(err.Error(), constants.ErrAuthorNotFound) {
-			helpers.Logger.Error("api::GetDetailAuthor - Author not found")
-			return &author.AuthorResponse{
-				Message: constants.ErrAuthorNotFound,
-				Data:    nil,
-			}, nil
-		}
-
 		helpers.Logger.Error("api::GetDetailAuthor - Failed to get detail Author : ", err)
-		return &author.AuthorResponse{
-			Message: "Failed to get detail Author",
-			Data:    nil,
-		}, nil
+		return nil, domain.GRPCError(err)
+	}
+
+	return &author.AuthorResponse{
+		Message: constants.SuccessMessage,
+		Data:    toAuthorData(res),
+	}, nil
+}
+
+func (api *AuthorAPI) CreateAuthor(ctx context.Context, req *author.CreateAuthorRequest) (*author.AuthorResponse, error) {
+	internalReq := dto.CreateAuthorRequest{
+		Name:      req.Name,
+		Bio:       req.Bio,
+		BirthDate: req.BirthDate,
+	}
+
+	if err := api.Validator.Validate(internalReq); err != nil {
+		helpers.Logger.Error("api::CreateAuthor - Failed to validate request : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	res, err := api.AuthorService.CreateAuthor(ctx, &internalReq)
+	if err != nil {
+		helpers.Logger.Error("api::CreateAuthor - Failed to create Author : ", err)
+		return nil, domain.GRPCError(err)
 	}
 
 	return &author.AuthorResponse{
 		Message: constants.SuccessMessage,
-		Data: &author.AuthorData{
-			Id:   res.ID,
-			Name: res.Name,
-		},
+		Data:    toAuthorData(res),
+	}, nil
+}
+
+func (api *AuthorAPI) ListAuthors(ctx context.Context, req *author.ListAuthorsRequest) (*author.ListAuthorsResponse, error) {
+	internalReq := dto.GetListAuthorRequest{
+		Page:       int(req.Page),
+		Limit:      int(req.Limit),
+		Name:       req.Name,
+		BornAfter:  req.BornAfter,
+		BornBefore: req.BornBefore,
+		DiedAfter:  req.DiedAfter,
+		DiedBefore: req.DiedBefore,
+		IsLiving:   req.IsLiving,
+		Sort:       req.Sort,
+		Order:      req.Order,
+		Cursor:     req.Cursor,
+	}
+
+	if err := api.Validator.Validate(internalReq); err != nil {
+		helpers.Logger.Error("api::ListAuthors - Failed to validate request : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	if internalReq.Page <= 0 {
+		internalReq.Page = 1
+	}
+
+	if internalReq.Limit <= 0 {
+		internalReq.Limit = 10
+	}
+
+	res, err := api.AuthorService.GetListAuthor(ctx, &internalReq)
+	if err != nil {
+		helpers.Logger.Error("api::ListAuthors - Failed to get list Author : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	return &author.ListAuthorsResponse{
+		Message:    constants.SuccessMessage,
+		Data:       toAuthorList(res.AuthorList),
+		Pagination: toPagination(res.Pagination),
+	}, nil
+}
+
+func (api *AuthorAPI) UpdateAuthor(ctx context.Context, req *author.UpdateAuthorRequest) (*author.MessageResponse, error) {
+	if paths := req.GetUpdateMask().GetPaths(); len(paths) > 0 {
+		return api.patchAuthor(ctx, req, paths)
+	}
+
+	internalReq := dto.UpdateAuthorRequest{
+		ID:        req.Id,
+		Name:      req.Name,
+		Bio:       req.Bio,
+		BirthDate: req.BirthDate,
+		DeathDate: req.DeathDate,
+
+		ExpectedVersion: int(req.ExpectedVersion),
+	}
+
+	if err := api.Validator.Validate(internalReq); err != nil {
+		helpers.Logger.Error("api::UpdateAuthor - Failed to validate request : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	if !helpers.IsValidUUID(internalReq.ID) {
+		helpers.Logger.Error("api::UpdateAuthor - Invalid UUID format for parameter: id")
+		return nil, domain.GRPCError(domain.InvalidUUID("id"))
+	}
+
+	err := api.AuthorService.UpdateAuthor(ctx, &internalReq)
+	if err != nil {
+		helpers.Logger.Error("api::UpdateAuthor - Failed to update Author : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	return &author.MessageResponse{
+		Message: constants.SuccessMessage,
+	}, nil
+}
+
+// patchAuthor writes only the fields named in paths. Proto3 strings cannot be
+// null, so an empty bio or death_date in the mask clears that field.
+func (api *AuthorAPI) patchAuthor(ctx context.Context, req *author.UpdateAuthorRequest, paths []string) (*author.MessageResponse, error) {
+	if !helpers.IsValidUUID(req.Id) {
+		helpers.Logger.Error("api::UpdateAuthor - Invalid UUID format for parameter: id")
+		return nil, domain.GRPCError(domain.InvalidUUID("id"))
+	}
+
+	internalReq := dto.PatchAuthorRequest{
+		ID:              req.Id,
+		Fields:          paths,
+		ExpectedVersion: int(req.ExpectedVersion),
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "name":
+			internalReq.Name = &req.Name
+		case "bio":
+			if req.Bio != "" {
+				internalReq.Bio = &req.Bio
+			}
+		case "birth_date":
+			internalReq.BirthDate = &req.BirthDate
+		case "death_date":
+			if req.DeathDate != "" {
+				internalReq.DeathDate = &req.DeathDate
+			}
+		}
+	}
+
+	if err := api.Validator.Validate(internalReq); err != nil {
+		helpers.Logger.Error("api::UpdateAuthor - Failed to validate request : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	_, err := api.AuthorService.PatchAuthor(ctx, &internalReq)
+	if err != nil {
+		helpers.Logger.Error("api::UpdateAuthor - Failed to patch Author : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	return &author.MessageResponse{
+		Message: constants.SuccessMessage,
+	}, nil
+}
+
+func (api *AuthorAPI) DeleteAuthor(ctx context.Context, req *author.DeleteAuthorRequest) (*author.MessageResponse, error) {
+	if !helpers.IsValidUUID(req.Id) {
+		helpers.Logger.Error("api::DeleteAuthor - Invalid UUID format for parameter: id")
+		return nil, domain.GRPCError(domain.InvalidUUID("id"))
+	}
+
+	err := api.AuthorService.DeleteAuthor(ctx, req.Id, int(req.ExpectedVersion))
+	if err != nil {
+		helpers.Logger.Error("api::DeleteAuthor - Failed to delete Author : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	return &author.MessageResponse{
+		Message: constants.SuccessMessage,
+	}, nil
+}
+
+func (api *AuthorAPI) BatchGetAuthors(ctx context.Context, req *author.BatchGetAuthorsRequest) (*author.BatchGetAuthorsResponse, error) {
+	internalReq := dto.BatchGetAuthorsRequest{
+		IDs: req.Ids,
+	}
+
+	if err := api.Validator.Validate(internalReq); err != nil {
+		helpers.Logger.Error("api::BatchGetAuthors - Failed to validate request : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	res, err := api.AuthorService.BatchGetAuthors(ctx, internalReq.IDs)
+	if err != nil {
+		helpers.Logger.Error("api::BatchGetAuthors - Failed to get Author batch : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	return &author.BatchGetAuthorsResponse{
+		Message:    constants.SuccessMessage,
+		Data:       toAuthorList(res.AuthorList),
+		MissingIds: res.MissingIDs,
 	}, nil
 }
+
+func (api *AuthorAPI) SearchAuthors(ctx context.Context, req *author.SearchAuthorsRequest) (*author.SearchAuthorsResponse, error) {
+	internalReq := dto.SearchAuthorRequest{
+		Query: req.Query,
+		Page:  int(req.Page),
+		Limit: int(req.Limit),
+	}
+
+	if err := api.Validator.Validate(internalReq); err != nil {
+		helpers.Logger.Error("api::SearchAuthors - Failed to validate request : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	if internalReq.Page <= 0 {
+		internalReq.Page = 1
+	}
+
+	if internalReq.Limit <= 0 {
+		internalReq.Limit = 10
+	}
+
+	res, err := api.AuthorService.SearchAuthors(ctx, &internalReq)
+	if err != nil {
+		helpers.Logger.Error("api::SearchAuthors - Failed to search Author : ", err)
+		return nil, domain.GRPCError(err)
+	}
+
+	data := make([]*author.AuthorSearchResult, 0, len(res.AuthorList))
+	for _, item := range res.AuthorList {
+		data = append(data, &author.AuthorSearchResult{
+			Author:        toAuthorItem(item.Author),
+			Rank:          item.Rank,
+			NameHighlight: item.NameHighlight,
+			BioSnippet:    item.BioSnippet,
+		})
+	}
+
+	return &author.SearchAuthorsResponse{
+		Message:    constants.SuccessMessage,
+		Data:       data,
+		Pagination: toPagination(res.Pagination),
+	}, nil
+}
+
+// WatchAuthors streams author changes until the client goes away. Clients
+// reconnect with the resume_token of the last event they processed.
+func (api *AuthorAPI) WatchAuthors(req *author.WatchRequest, stream author.AuthorService_WatchAuthorsServer) error {
+	internalReq := dto.WatchAuthorsRequest{
+		ResumeToken: req.ResumeToken,
+		AuthorIDs:   req.AuthorIds,
+	}
+
+	if err := api.Validator.Validate(internalReq); err != nil {
+		helpers.Logger.Error("api::WatchAuthors - Failed to validate request : ", err)
+		return domain.GRPCError(err)
+	}
+
+	err := api.AuthorService.WatchAuthorEvents(stream.Context(), &internalReq, func(event dto.AuthorEvent) error {
+		return stream.Send(&author.AuthorEvent{
+			ResumeToken: event.ID,
+			EventType:   event.EventType,
+			Author:      toAuthorItem(event.Author),
+			MergedInto:  event.MergedInto,
+			OccurredAt:  event.OccurredAt,
+		})
+	})
+	if err != nil {
+		helpers.Logger.Error("api::WatchAuthors - Failed to watch Author events : ", err)
+		return domain.GRPCError(err)
+	}
+
+	return nil
+}
+
+func toAuthorData(res *dto.GetDetailAuthorResponse) *author.AuthorData {
+	return &author.AuthorData{
+		Id:        res.ID,
+		Name:      res.Name,
+		Bio:       res.Bio,
+		BirthDate: res.BirthDate,
+		DeathDate: res.DeathDate,
+		Version:   int32(res.Version),
+		CreatedAt: res.CreatedAt,
+		UpdatedAt: res.UpdatedAt,
+	}
+}
+
+func toAuthorList(list []dto.Author) []*author.AuthorData {
+	data := make([]*author.AuthorData, 0, len(list))
+	for _, item := range list {
+		data = append(data, toAuthorItem(item))
+	}
+
+	return data
+}
+
+func toAuthorItem(item dto.Author) *author.AuthorData {
+	return &author.AuthorData{
+		Id:        item.ID,
+		Name:      item.Name,
+		Bio:       item.Bio,
+		BirthDate: item.BirthDate,
+		DeathDate: item.DeathDate,
+		Version:   int32(item.Version),
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+		DeletedAt: item.DeletedAt,
+	}
+}
+
+func toPagination(pagination dto.Pagination) *author.Pagination {
+	return &author.Pagination{
+		Page:       int32(pagination.Page),
+		Limit:      int32(pagination.Limit),
+		TotalItems: int32(pagination.TotalItems),
+		TotalPages: int32(pagination.TotalPages),
+		NextCursor: pagination.NextCursor,
+		PrevCursor: pagination.PrevCursor,
+	}
+}
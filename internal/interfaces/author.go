@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hilmiikhsan/library-author-service/cmd/proto/author"
@@ -10,29 +11,87 @@ import (
 )
 
 type IAuthorRepository interface {
-	InsertNewAuthor(ctx context.Context, author *models.Author) error
+	InsertNewAuthor(ctx context.Context, author *models.Author, actor models.TokenData) error
+	InsertNewAuthors(ctx context.Context, authors []*models.Author, actor models.TokenData) error
+	FindExistingAuthorIdentities(ctx context.Context, identities []models.AuthorIdentity) ([]models.AuthorIdentity, error)
 	FindAuthorByID(ctx context.Context, id string) (*models.Author, error)
-	FindAllAuthor(ctx context.Context, limit, offset int) ([]models.Author, error)
-	UpdateNewAuthor(ctx context.Context, author *models.Author) error
-	DeleteAuthorByID(ctx context.Context, id string) error
+	FindAuthorsByIDs(ctx context.Context, ids []string) ([]models.Author, error)
+	FindAllAuthor(ctx context.Context, filter models.AuthorFilter) ([]models.Author, int, error)
+	StreamAuthors(ctx context.Context, filter models.AuthorFilter, fn func(models.Author) error) error
+	SearchAuthors(ctx context.Context, query string, limit, offset int) ([]models.AuthorSearchResult, error)
+	UpdateNewAuthor(ctx context.Context, author *models.Author, revertedFrom int, actor models.TokenData) error
+	DeleteAuthorByID(ctx context.Context, id string, expectedVersion int, actor models.TokenData) error
+	RestoreAuthorByID(ctx context.Context, id string, actor models.TokenData) error
+	PurgeDeletedAuthors(ctx context.Context, retention time.Duration) (int64, error)
+	FindDuplicateCandidates(ctx context.Context, filter models.AuthorDuplicateFilter) ([]models.AuthorDuplicate, int, error)
+	MergeAuthors(ctx context.Context, sourceID, targetID string, actor models.TokenData) (*models.Author, error)
+	FindAuthorRedirect(ctx context.Context, id string) (string, error)
+	FindAuthorVersions(ctx context.Context, id string, limit, offset int) ([]models.AuthorVersion, int, error)
+	FindAuthorVersion(ctx context.Context, id string, version int) (*models.AuthorVersion, error)
+	RelayAuthorEvents(ctx context.Context, limit int, publish func(context.Context, models.AuthorEvent) error) (int, error)
+	FindAuthorEventsAfter(ctx context.Context, afterSequence int64, limit int) ([]models.AuthorEvent, error)
+	FindLatestAuthorEventSequence(ctx context.Context) (int64, error)
+}
+
+type IAuthorCache interface {
+	AuthorKey(id string) string
+	ListKey(ctx context.Context, key string) string
+	Get(ctx context.Context, key string, dest any) bool
+	Set(ctx context.Context, key string, value any)
+	GetAuthors(ctx context.Context, ids []string) ([]models.Author, []string)
+	SetAuthors(ctx context.Context, authors ...models.Author)
+	InvalidateAuthor(ctx context.Context, id string)
+	InvalidateList(ctx context.Context)
 }
 
 type IAuthorService interface {
-	CreateAuthor(ctx context.Context, req *dto.CreateAuthorRequest) error
+	CreateAuthor(ctx context.Context, req *dto.CreateAuthorRequest) (*dto.GetDetailAuthorResponse, error)
 	GetDetailAuthor(ctx context.Context, id string) (*dto.GetDetailAuthorResponse, error)
-	GetListAuthor(ctx context.Context, limit, offset int) (*dto.GetListAuthorResponse, error)
+	BatchGetAuthors(ctx context.Context, ids []string) (*dto.BatchGetAuthorsResponse, error)
+	GetListAuthor(ctx context.Context, req *dto.GetListAuthorRequest) (*dto.GetListAuthorResponse, error)
+	SearchAuthors(ctx context.Context, req *dto.SearchAuthorRequest) (*dto.SearchAuthorResponse, error)
 	UpdateAuthor(ctx context.Context, req *dto.UpdateAuthorRequest) error
-	DeleteAuthor(ctx context.Context, id string) error
+	PatchAuthor(ctx context.Context, req *dto.PatchAuthorRequest) (*dto.GetDetailAuthorResponse, error)
+	ImportAuthors(ctx context.Context, req *dto.ImportAuthorsRequest) (*dto.ImportAuthorsResponse, error)
+	ExportAuthors(ctx context.Context, req *dto.ExportAuthorsRequest, send func(dto.Author) error) error
+	GetDuplicateCandidates(ctx context.Context, req *dto.GetDuplicateCandidatesRequest) (*dto.GetDuplicateCandidatesResponse, error)
+	MergeAuthors(ctx context.Context, req *dto.MergeAuthorsRequest) (*dto.GetDetailAuthorResponse, error)
+	DeleteAuthor(ctx context.Context, id string, expectedVersion int) error
+	RestoreAuthor(ctx context.Context, id string) error
+	PurgeDeletedAuthors(ctx context.Context, retention time.Duration) (int64, error)
+	WatchAuthorEvents(ctx context.Context, req *dto.WatchAuthorsRequest, send func(dto.AuthorEvent) error) error
+	GetAuthorHistory(ctx context.Context, req *dto.GetAuthorHistoryRequest) (*dto.GetAuthorHistoryResponse, error)
+	GetAuthorVersion(ctx context.Context, id string, version int) (*dto.AuthorVersion, error)
+	RevertAuthor(ctx context.Context, id string, version, expectedVersion int) (*dto.GetDetailAuthorResponse, error)
 }
 
 type IAuthorHandler interface {
 	CreateAuthor(*gin.Context)
 	GetDetailAuthor(*gin.Context)
+	BatchGetAuthors(*gin.Context)
 	GetListAuthor(*gin.Context)
+	SearchAuthors(*gin.Context)
 	UpdateAuthor(*gin.Context)
+	PatchAuthor(*gin.Context)
 	DeleteAuthor(*gin.Context)
+	RestoreAuthor(*gin.Context)
+	GetAuthorHistory(*gin.Context)
+	GetAuthorVersion(*gin.Context)
+	RevertAuthor(*gin.Context)
+	StreamAuthorEvents(*gin.Context)
+	ImportAuthors(*gin.Context)
+	ExportAuthors(*gin.Context)
+	GetDuplicateCandidates(*gin.Context)
+	MergeAuthors(*gin.Context)
 }
 
 type IAuthorAPI interface {
 	GetDetailAuthor(ctx context.Context, req *author.AuthorRequest) (*author.AuthorResponse, error)
+	CreateAuthor(ctx context.Context, req *author.CreateAuthorRequest) (*author.AuthorResponse, error)
+	ListAuthors(ctx context.Context, req *author.ListAuthorsRequest) (*author.ListAuthorsResponse, error)
+	UpdateAuthor(ctx context.Context, req *author.UpdateAuthorRequest) (*author.MessageResponse, error)
+	DeleteAuthor(ctx context.Context, req *author.DeleteAuthorRequest) (*author.MessageResponse, error)
+	BatchGetAuthors(ctx context.Context, req *author.BatchGetAuthorsRequest) (*author.BatchGetAuthorsResponse, error)
+	SearchAuthors(ctx context.Context, req *author.SearchAuthorsRequest) (*author.SearchAuthorsResponse, error)
+	WatchAuthors(req *author.WatchRequest, stream author.AuthorService_WatchAuthorsServer) error
 }
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+)
+
+type IEventPublisher interface {
+	Publish(ctx context.Context, event models.AuthorEvent) error
+}
+
+type IEventNotifier interface {
+	Subscribe() (<-chan struct{}, func())
+}
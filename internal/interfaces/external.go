@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/hilmiikhsan/library-author-service/internal/models"
 )
@@ -9,3 +10,17 @@ import (
 type IExternal interface {
 	ValidateToken(ctx context.Context, token string) (models.TokenData, error)
 }
+
+type ITokenCache interface {
+	Get(ctx context.Context, token string) (models.TokenData, bool)
+	Set(ctx context.Context, token string, data models.TokenData, expiresAt time.Time)
+}
+
+type ICircuitBreaker interface {
+	Allow() bool
+	Success()
+	Failure()
+	Release()
+	State() string
+	Failures() int
+}
@@ -1,9 +1,12 @@
 package interfaces
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+)
 
 type IHealthcheckServices interface {
-	HealthcheckServices() (string, error)
+	HealthcheckServices() (dto.Healthcheck, string, error)
 }
 
 type IHealthcheckHandler interface {
@@ -0,0 +1,42 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+)
+
+type ILocker interface {
+	Acquire(ctx context.Context, name string, ttl time.Duration) (string, bool, error)
+	Refresh(ctx context.Context, name, token string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, name, token string)
+}
+
+type IImportJobRepository interface {
+	InsertImportJob(ctx context.Context, job *models.ImportJob) error
+	FindImportJobByID(ctx context.Context, id string) (*models.ImportJob, error)
+	FindPendingImportJobIDs(ctx context.Context, limit int) ([]string, error)
+	StartImportJob(ctx context.Context, id string) (*models.ImportJob, error)
+	UpdateImportJobProgress(ctx context.Context, id string, progress models.ImportJobProgress) error
+	FinishImportJob(ctx context.Context, id, status, errorMessage string) error
+	CancelImportJob(ctx context.Context, id string) error
+	FindImportJobRowErrors(ctx context.Context, id string) ([]models.ImportJobRowError, error)
+}
+
+type IImportJobService interface {
+	CreateImportJob(ctx context.Context, req *dto.CreateImportJobRequest) (*dto.ImportJob, error)
+	GetImportJob(ctx context.Context, id string) (*dto.ImportJob, error)
+	CancelImportJob(ctx context.Context, id string) (*dto.ImportJob, error)
+	GetImportJobErrors(ctx context.Context, id string) ([]dto.ImportAuthorResult, error)
+	FindPendingImportJobIDs(ctx context.Context, limit int) ([]string, error)
+	ProcessImportJob(ctx context.Context, id string) error
+}
+
+type IImportJobHandler interface {
+	GetImportJob(*gin.Context)
+	CancelImportJob(*gin.Context)
+	DownloadImportJobErrors(*gin.Context)
+}
@@ -0,0 +1,5 @@
+package interfaces
+
+type IPolicy interface {
+	Allows(role string, permissions ...string) bool
+}
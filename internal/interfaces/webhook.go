@@ -0,0 +1,39 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+)
+
+type IWebhookRepository interface {
+	InsertSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	FindSubscriptions(ctx context.Context, limit, offset int) ([]models.WebhookSubscription, int, error)
+	DeleteSubscription(ctx context.Context, id string) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id int64, statusCode int) error
+	MarkFailed(ctx context.Context, id int64, maxAttempts int, backoff time.Duration, statusCode int, lastError string) error
+	RetryDelivery(ctx context.Context, id int64) error
+	FindDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, int, error)
+}
+
+type IWebhookService interface {
+	CreateWebhook(ctx context.Context, req *dto.CreateWebhookRequest) (*dto.Webhook, error)
+	GetListWebhook(ctx context.Context, req *dto.GetListWebhookRequest) (*dto.GetListWebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	GetWebhookDeliveries(ctx context.Context, req *dto.GetWebhookDeliveriesRequest) (*dto.GetWebhookDeliveriesResponse, error)
+	RetryWebhookDelivery(ctx context.Context, id int64) error
+	DispatchDueDeliveries(ctx context.Context, limit int) (int, error)
+}
+
+type IWebhookHandler interface {
+	CreateWebhook(*gin.Context)
+	GetListWebhook(*gin.Context)
+	DeleteWebhook(*gin.Context)
+	GetWebhookDeliveries(*gin.Context)
+	GetDeadLetters(*gin.Context)
+	RetryWebhookDelivery(*gin.Context)
+}
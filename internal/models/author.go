@@ -13,6 +13,86 @@ type Author struct {
 	Bio       string       `db:"bio"`
 	BirthDate time.Time    `db:"birth_date"`
 	DeathDate sql.NullTime `db:"death_date"`
+	Version   int          `db:"version"`
 	CreatedAt time.Time    `db:"created_at"`
 	UpdatedAt time.Time    `db:"updated_at"`
+	DeletedAt sql.NullTime `db:"deleted_at"`
+}
+
+// AuthorIdentity is what the importer treats as the same author.
+type AuthorIdentity struct {
+	Name      string    `db:"name"`
+	BirthDate time.Time `db:"birth_date"`
+}
+
+type AuthorSearchResult struct {
+	Author
+	Rank          float64 `db:"rank"`
+	NameHighlight string  `db:"name_highlight"`
+	BioSnippet    string  `db:"bio_snippet"`
+	TotalItems    int     `db:"total_items"`
+}
+
+// AuthorDuplicate is a pair of active authors that may be the same person.
+// Score weighs NameScore, the trigram similarity of their normalized names,
+// with how well their birth and death dates agree.
+type AuthorDuplicate struct {
+	Author     Author  `db:"author"`
+	Duplicate  Author  `db:"duplicate"`
+	NameScore  float64 `db:"name_score"`
+	Score      float64 `db:"score"`
+	TotalItems int     `db:"total_items"`
+}
+
+// AuthorDuplicateFilter narrows FindDuplicateCandidates. With AuthorID set
+// only pairs involving that author are returned.
+type AuthorDuplicateFilter struct {
+	AuthorID string
+	MinScore float64
+	Limit    int
+	Offset   int
+}
+
+// AuthorFilter narrows and orders FindAllAuthor. Date bounds are inclusive
+// and ignored when zero. Soft-deleted authors are skipped unless
+// IncludeDeleted is set.
+type AuthorFilter struct {
+	IncludeDeleted bool
+	Name           string
+	BornAfter      time.Time
+	BornBefore     time.Time
+	DiedAfter      time.Time
+	DiedBefore     time.Time
+	IsLiving       *bool
+	SortBy         string
+	SortDesc       bool
+	Cursor         *AuthorCursor
+	Limit          int
+	Offset         int
+}
+
+// AuthorCursor is a keyset position on (updated_at, id) in a list sorted
+// descending when Desc. Results are read after the position when paging
+// forward and before it when Backward.
+type AuthorCursor struct {
+	UpdatedAt string
+	ID        string
+	Backward  bool
+	Desc      bool
+}
+
+type AuthorVersion struct {
+	ID                int64         `db:"id"`
+	AuthorID          uuid.UUID     `db:"author_id"`
+	Version           int           `db:"version"`
+	Operation         string        `db:"operation"`
+	Name              string        `db:"name"`
+	Bio               string        `db:"bio"`
+	BirthDate         time.Time     `db:"birth_date"`
+	DeathDate         sql.NullTime  `db:"death_date"`
+	DeletedAt         sql.NullTime  `db:"deleted_at"`
+	RevertedFrom      sql.NullInt64 `db:"reverted_from"`
+	ChangedBy         string        `db:"changed_by"`
+	ChangedByUsername string        `db:"changed_by_username"`
+	ChangedAt         time.Time     `db:"changed_at"`
 }
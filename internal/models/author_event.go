@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx/types"
+)
+
+// AuthorEvent is an outbox row. Payload is the author row as it was right
+// after the write that produced the event. Sequence is its position in
+// commit order, assigned by the outbox relay.
+type AuthorEvent struct {
+	ID        int64          `db:"id"`
+	Sequence  int64          `db:"sequence"`
+	AuthorID  uuid.UUID      `db:"author_id"`
+	EventType string         `db:"event_type"`
+	Payload   types.JSONText `db:"payload"`
+	CreatedAt time.Time      `db:"created_at"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx/types"
+)
+
+type ImportJob struct {
+	ID                uuid.UUID    `db:"id"`
+	Status            string       `db:"status"`
+	FileName          string       `db:"file_name"`
+	Format            string       `db:"format"`
+	DryRun            bool         `db:"dry_run"`
+	TotalRows         int          `db:"total_rows"`
+	ProcessedRows     int          `db:"processed_rows"`
+	CreatedRows       int          `db:"created_rows"`
+	ValidRows         int          `db:"valid_rows"`
+	DuplicateRows     int          `db:"duplicate_rows"`
+	InvalidRows       int          `db:"invalid_rows"`
+	FailedRows        int          `db:"failed_rows"`
+	ErrorMessage      string       `db:"error_message"`
+	CreatedByID       string       `db:"created_by_id"`
+	CreatedByUsername string       `db:"created_by_username"`
+	CreatedAt         time.Time    `db:"created_at"`
+	UpdatedAt         time.Time    `db:"updated_at"`
+	StartedAt         sql.NullTime `db:"started_at"`
+	FinishedAt        sql.NullTime `db:"finished_at"`
+
+	// Rows and RowErrors are only loaded by the queries that need them.
+	Rows      types.JSONText `db:"rows"`
+	RowErrors types.JSONText `db:"row_errors"`
+}
+
+// ImportJobRow is a parsed import record as stored with its job.
+type ImportJobRow struct {
+	Line      int                 `json:"line"`
+	Name      string              `json:"name"`
+	Bio       string              `json:"bio"`
+	BirthDate string              `json:"birth_date"`
+	DeathDate string              `json:"death_date,omitempty"`
+	Errors    map[string][]string `json:"errors,omitempty"`
+}
+
+// ImportJobRowError is a row of the job error file.
+type ImportJobRowError struct {
+	Line   int                 `json:"line"`
+	Name   string              `json:"name,omitempty"`
+	Status string              `json:"status"`
+	Reason string              `json:"reason,omitempty"`
+	Errors map[string][]string `json:"errors,omitempty"`
+}
+
+// ImportJobProgress is the outcome of one processed chunk of a job.
+type ImportJobProgress struct {
+	Processed  int
+	Created    int
+	Valid      int
+	Duplicates int
+	Invalid    int
+	Failed     int
+	RowErrors  []ImportJobRowError
+}
@@ -0,0 +1,104 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/pkg/errors"
+)
+
+// Permissions lists every permission a policy may grant.
+var Permissions = []string{
+	constants.PermissionAuthorRead,
+	constants.PermissionAuthorReadDeleted,
+	constants.PermissionAuthorWrite,
+	constants.PermissionAuthorDelete,
+	constants.PermissionAuthorImport,
+	constants.PermissionWebhookManage,
+}
+
+// Policy maps roles to the permissions they are granted. Roles it does not
+// name are granted nothing.
+type Policy struct {
+	roles map[string]map[string]struct{}
+}
+
+// Default grants every permission to Admin, read access to User and author
+// reads and writes to Service, the role of trusted internal callers.
+func Default() *Policy {
+	p, _ := New(map[string][]string{
+		constants.AuthRoleAdmin:   Permissions,
+		constants.AuthRoleUser:    {constants.PermissionAuthorRead},
+		constants.AuthRoleService: {constants.PermissionAuthorRead, constants.PermissionAuthorWrite, constants.PermissionAuthorDelete},
+	})
+
+	return p
+}
+
+// New builds a policy from role names to permissions. "*" grants every
+// permission; any other unknown permission is an error so that a typo in the
+// config cannot silently lock a role out.
+func New(roles map[string][]string) (*Policy, error) {
+	known := make(map[string]struct{}, len(Permissions))
+	for _, permission := range Permissions {
+		known[permission] = struct{}{}
+	}
+
+	p := &Policy{roles: make(map[string]map[string]struct{}, len(roles))}
+	for role, permissions := range roles {
+		granted := make(map[string]struct{}, len(permissions))
+		for _, permission := range permissions {
+			if permission == "*" {
+				for name := range known {
+					granted[name] = struct{}{}
+				}
+				continue
+			}
+
+			if _, ok := known[permission]; !ok {
+				return nil, fmt.Errorf("role %q: unknown permission %q", role, permission)
+			}
+			granted[permission] = struct{}{}
+		}
+		p.roles[role] = granted
+	}
+
+	return p, nil
+}
+
+// Load reads a policy from a JSON file of the form
+// {"roles": {"Admin": ["*"], "User": ["author:read"]}}. An empty path yields
+// Default.
+func Load(path string) (*Policy, error) {
+	if path == "" {
+		return Default(), nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read policy file")
+	}
+
+	var doc struct {
+		Roles map[string][]string `json:"roles"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, errors.Wrap(err, "failed to decode policy file")
+	}
+
+	return New(doc.Roles)
+}
+
+// Allows reports whether role holds every one of permissions.
+func (p *Policy) Allows(role string, permissions ...string) bool {
+	granted := p.roles[role]
+	for _, permission := range permissions {
+		if _, ok := granted[permission]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
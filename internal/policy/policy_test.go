@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "valid", content: `{"roles": {"Admin": ["*"], "User": ["author:read"]}}`},
+		{name: "no roles", content: `{}`},
+		{name: "unknown permission", content: `{"roles": {"User": ["author:read", "author:publish"]}}`, wantErr: true},
+		{name: "misspelt permission", content: `{"roles": {"User": ["author:reads"]}}`, wantErr: true},
+		{name: "permission with different case", content: `{"roles": {"User": ["Author:Read"]}}`, wantErr: true},
+		{name: "empty permission", content: `{"roles": {"User": [""]}}`, wantErr: true},
+		{name: "unknown permission next to wildcard", content: `{"roles": {"Admin": ["*", "author:publish"]}}`, wantErr: true},
+		{name: "permissions not a list", content: `{"roles": {"User": "author:read"}}`, wantErr: true},
+		{name: "malformed json", content: `{"roles": `, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "policy.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && p == nil {
+				t.Fatal("Load() returned a nil policy without an error")
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("Load() error = nil, want an error for a missing file")
+	}
+}
+
+// TestLoadShippedPolicy keeps policy.json in step with Default.
+func TestLoadShippedPolicy(t *testing.T) {
+	shipped, err := Load(filepath.Join("..", "..", "policy.json"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	defaults := Default()
+	for _, role := range []string{constants.AuthRoleAdmin, constants.AuthRoleUser, constants.AuthRoleService} {
+		for _, permission := range Permissions {
+			if got, want := shipped.Allows(role, permission), defaults.Allows(role, permission); got != want {
+				t.Errorf("policy.json Allows(%q, %q) = %v, Default() = %v", role, permission, got, want)
+			}
+		}
+	}
+}
+
+func TestPolicyAllows(t *testing.T) {
+	p, err := New(map[string][]string{
+		constants.AuthRoleAdmin: {"*"},
+		constants.AuthRoleUser:  {constants.PermissionAuthorRead},
+		"Editor":                {constants.PermissionAuthorRead, constants.PermissionAuthorWrite},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		role        string
+		permissions []string
+		want        bool
+	}{
+		{name: "wildcard grants everything", role: constants.AuthRoleAdmin, permissions: Permissions, want: true},
+		{name: "granted permission", role: constants.AuthRoleUser, permissions: []string{constants.PermissionAuthorRead}, want: true},
+		{name: "missing permission", role: constants.AuthRoleUser, permissions: []string{constants.PermissionAuthorWrite}},
+		{name: "all of several", role: "Editor", permissions: []string{constants.PermissionAuthorRead, constants.PermissionAuthorWrite}, want: true},
+		{name: "only some of several", role: "Editor", permissions: []string{constants.PermissionAuthorRead, constants.PermissionAuthorDelete}},
+		{name: "unknown role", role: "Guest", permissions: []string{constants.PermissionAuthorRead}},
+		{name: "empty role", role: "", permissions: []string{constants.PermissionAuthorRead}},
+		{name: "role names are case sensitive", role: "admin", permissions: []string{constants.PermissionAuthorRead}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Allows(tt.role, tt.permissions...); got != tt.want {
+				t.Errorf("Allows(%q, %v) = %v, want %v", tt.role, tt.permissions, got, tt.want)
+			}
+		})
+	}
+}
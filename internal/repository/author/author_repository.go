@@ -3,114 +3,718 @@ package author
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
 	"github.com/hilmiikhsan/library-author-service/internal/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
 type AuthorRepository struct {
 	DB     *sqlx.DB
 	Logger *logrus.Logger
-	Redis  *redis.Client
+	Cache  interfaces.IAuthorCache
 }
 
-func (r *AuthorRepository) InsertNewAuthor(ctx context.Context, author *models.Author) error {
-	_, err := r.DB.ExecContext(ctx, r.DB.Rebind(queryInsertNewAuthor),
-		author.Name,
-		author.Bio,
-		author.BirthDate,
-	)
+// InsertNewAuthor inserts author together with its first version and created
+// event.
+func (r *AuthorRepository) InsertNewAuthor(ctx context.Context, author *models.Author, actor models.TokenData) error {
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowxContext(ctx, tx.Rebind(queryInsertNewAuthor),
+			author.Name,
+			author.Bio,
+			author.BirthDate,
+		).Scan(&author.ID, &author.Version, &author.CreatedAt, &author.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		if err := insertAuthorVersion(ctx, tx, author.ID.String(), constants.AuthorOperationCreate, 0, actor); err != nil {
+			return err
+		}
+
+		return insertAuthorEvent(ctx, tx, author.ID.String(), constants.AuthorEventCreated)
+	})
 	if err != nil {
 		r.Logger.Error("author::InsertNewAuthor - failed to insert new Author: ", err)
-		return err
+		return domain.FromDatabase(err)
 	}
 
+	r.Cache.InvalidateList(ctx)
+
 	return nil
 }
 
+// InsertNewAuthors inserts authors in a single transaction together with
+// their first version and created events. Either every author is written or
+// none is.
+func (r *AuthorRepository) InsertNewAuthors(ctx context.Context, authors []*models.Author, actor models.TokenData) error {
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		for _, author := range authors {
+			err := tx.QueryRowxContext(ctx, tx.Rebind(queryInsertImportedAuthor),
+				author.Name,
+				author.Bio,
+				author.BirthDate,
+				author.DeathDate,
+			).Scan(&author.ID, &author.Version, &author.CreatedAt, &author.UpdatedAt)
+			if err != nil {
+				return err
+			}
+
+			if err := insertAuthorVersion(ctx, tx, author.ID.String(), constants.AuthorOperationCreate, 0, actor); err != nil {
+				return err
+			}
+
+			if err := insertAuthorEvent(ctx, tx, author.ID.String(), constants.AuthorEventCreated); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.Logger.Error("author::InsertNewAuthors - failed to insert new authors: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	r.Cache.InvalidateList(ctx)
+
+	return nil
+}
+
+// FindExistingAuthorIdentities returns which of the given identities already
+// belong to an active author. Names come back lower-cased.
+func (r *AuthorRepository) FindExistingAuthorIdentities(ctx context.Context, identities []models.AuthorIdentity) ([]models.AuthorIdentity, error) {
+	var (
+		res        = make([]models.AuthorIdentity, 0)
+		names      = make([]string, 0, len(identities))
+		birthDates = make([]string, 0, len(identities))
+	)
+
+	for _, identity := range identities {
+		names = append(names, identity.Name)
+		birthDates = append(birthDates, identity.BirthDate.Format(constants.DateTimeFormat))
+	}
+
+	err := r.DB.SelectContext(ctx, &res, r.DB.Rebind(queryFindExistingAuthorIdentities), pq.Array(names), pq.Array(birthDates))
+	if err != nil {
+		r.Logger.Error("author::FindExistingAuthorIdentities - failed to find existing authors: ", err)
+		return nil, domain.FromDatabase(err)
+	}
+
+	return res, nil
+}
+
 func (r *AuthorRepository) FindAuthorByID(ctx context.Context, id string) (*models.Author, error) {
 	var (
-		res = new(models.Author)
+		res      = new(models.Author)
+		cacheKey = r.Cache.AuthorKey(id)
 	)
 
+	if r.Cache.Get(ctx, cacheKey, res) {
+		r.Logger.Info("author::FindAuthorByID - Data retrieved from cache")
+		return res, nil
+	}
+
 	err := r.DB.GetContext(ctx, res, r.DB.Rebind(queryFindAuthorByID), id)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			r.Logger.Error("author::FindAuthorByID - author doesnt exist")
-			return res, errors.New(constants.ErrAuthorNotFound)
+			return res, domain.NotFound(constants.ErrAuthorNotFound)
 		}
 
 		r.Logger.Error("author::FindAuthorByID - failed to find author by id: ", err)
-		return nil, err
+		return nil, domain.FromDatabase(err)
 	}
 
+	r.Cache.Set(ctx, cacheKey, res)
+
 	return res, nil
 }
 
-func (r *AuthorRepository) FindAllAuthor(ctx context.Context, limit, offset int) ([]models.Author, error) {
+func (r *AuthorRepository) FindAuthorsByIDs(ctx context.Context, ids []string) ([]models.Author, error) {
+	res, missIDs := r.Cache.GetAuthors(ctx, ids)
+	if len(missIDs) == 0 {
+		r.Logger.Info("author::FindAuthorsByIDs - Data retrieved from cache")
+		return res, nil
+	}
+
+	authors := make([]models.Author, 0, len(missIDs))
+	err := r.DB.SelectContext(ctx, &authors, r.DB.Rebind(queryFindAuthorsByIDs), pq.Array(missIDs))
+	if err != nil {
+		r.Logger.Error("author::FindAuthorsByIDs - failed to find authors by ids: ", err)
+		return nil, domain.FromDatabase(err)
+	}
+
+	r.Cache.SetAuthors(ctx, authors...)
+
+	return append(res, authors...), nil
+}
+
+// StreamAuthors calls fn for every author matching filter, reading them
+// through a server-side cursor authorExportFetchSize rows at a time so the
+// result set is never held in memory. Paging fields of filter are ignored.
+func (r *AuthorRepository) StreamAuthors(ctx context.Context, filter models.AuthorFilter, fn func(models.Author) error) error {
+	filter.Cursor = nil
+	where, orderBy, args := buildAuthorFilter(filter)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, tx.Rebind(queryDeclareAuthorExportCursor+queryFindAllAuthor+where+orderBy), args...)
+		if err != nil {
+			return err
+		}
+
+		fetch := fmt.Sprintf(queryFetchAuthorExportCursor, authorExportFetchSize)
+		for {
+			batch := make([]models.Author, 0, authorExportFetchSize)
+			if err := tx.SelectContext(ctx, &batch, fetch); err != nil {
+				return err
+			}
+
+			for _, author := range batch {
+				if err := fn(author); err != nil {
+					return err
+				}
+			}
+
+			if len(batch) < authorExportFetchSize {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		r.Logger.Error("author::StreamAuthors - failed to stream authors: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	return nil
+}
+
+func (r *AuthorRepository) FindAllAuthor(ctx context.Context, filter models.AuthorFilter) ([]models.Author, int, error) {
 	var (
-		res      = make([]models.Author, 0)
-		cacheKey = fmt.Sprintf("authors:limit:%d:offset:%d", limit, offset)
+		res = struct {
+			Authors []models.Author
+			Total   int
+		}{
+			Authors: make([]models.Author, 0),
+		}
+		cacheKey = r.Cache.ListKey(ctx, fmt.Sprintf("filter:%s", filterCacheKey(filter)))
 	)
 
-	cachedData, err := r.Redis.Get(ctx, cacheKey).Result()
-	if err == nil {
-		err = json.Unmarshal([]byte(cachedData), &res)
-		if err == nil {
-			r.Logger.Info("category::FindAllAuthor - Data retrieved from cache")
-			return res, nil
-		}
-		r.Logger.Warn("category::FindAllAuthor - Failed to unmarshal cache data: ", err)
+	if r.Cache.Get(ctx, cacheKey, &res) {
+		r.Logger.Info("author::FindAllAuthor - Data retrieved from cache")
+		return res.Authors, res.Total, nil
+	}
+
+	where, orderBy, args := buildAuthorFilter(filter)
+
+	err := r.DB.GetContext(ctx, &res.Total, r.DB.Rebind(queryCountAuthor+where), args...)
+	if err != nil {
+		r.Logger.Error("author::FindAllAuthor - failed to count author: ", err)
+		return nil, 0, domain.FromDatabase(err)
+	}
+
+	if filter.Cursor != nil {
+		where, orderBy = buildAuthorCursor(where, filter.Cursor)
+		args = append(args, filter.Cursor.UpdatedAt, filter.Cursor.ID)
 	}
 
-	err = r.DB.SelectContext(ctx, &res, r.DB.Rebind(queryFindAllAuthor), limit, offset)
+	query := queryFindAllAuthor + where + orderBy + " LIMIT ? OFFSET ?"
+	err = r.DB.SelectContext(ctx, &res.Authors, r.DB.Rebind(query), append(args, filter.Limit, filter.Offset)...)
 	if err != nil {
 		r.Logger.Error("author::FindAllAuthor - failed to find all author: ", err)
-		return nil, err
+		return nil, 0, domain.FromDatabase(err)
+	}
+
+	if filter.Cursor != nil && filter.Cursor.Backward {
+		for i, j := 0, len(res.Authors)-1; i < j; i, j = i+1, j-1 {
+			res.Authors[i], res.Authors[j] = res.Authors[j], res.Authors[i]
+		}
+	}
+
+	r.Cache.Set(ctx, cacheKey, res)
+
+	return res.Authors, res.Total, nil
+}
+
+func (r *AuthorRepository) SearchAuthors(ctx context.Context, query string, limit, offset int) ([]models.AuthorSearchResult, error) {
+	var (
+		res = make([]models.AuthorSearchResult, 0)
+	)
+
+	err := r.DB.SelectContext(ctx, &res, r.DB.Rebind(querySearchAuthors), query, query, limit, offset)
+	if err != nil {
+		r.Logger.Error("author::SearchAuthors - failed to search authors: ", err)
+		return nil, domain.FromDatabase(err)
+	}
+
+	return res, nil
+}
+
+// UpdateNewAuthor only writes when author.Version still matches the stored
+// row, or unconditionally when it is 0. On success author carries the new
+// version and updated_at. The write is recorded as an "update" version, or
+// as a "revert" from revertedFrom when that is set.
+func (r *AuthorRepository) UpdateNewAuthor(ctx context.Context, author *models.Author, revertedFrom int, actor models.TokenData) error {
+	operation := constants.AuthorOperationUpdate
+	if revertedFrom > 0 {
+		operation = constants.AuthorOperationRevert
+	}
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.QueryRowxContext(ctx, tx.Rebind(queryUpdateNewAuthor),
+			author.Name,
+			author.Bio,
+			author.BirthDate,
+			author.DeathDate,
+			author.ID,
+			author.Version,
+			author.Version,
+		).Scan(&author.Version, &author.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		if err := insertAuthorVersion(ctx, tx, author.ID.String(), operation, revertedFrom, actor); err != nil {
+			return err
+		}
+
+		return insertAuthorEvent(ctx, tx, author.ID.String(), constants.AuthorEventUpdated)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			r.Logger.Error("author::UpdateNewAuthor - author doesnt exist or version mismatch")
+			return versionedWriteError(author.Version)
+		}
+
+		r.Logger.Error("author::UpdateNewAuthor - failed to update new author: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	r.Cache.InvalidateAuthor(ctx, author.ID.String())
+
+	return nil
+}
+
+func (r *AuthorRepository) DeleteAuthorByID(ctx context.Context, id string, expectedVersion int, actor models.TokenData) error {
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, tx.Rebind(queryDeleteAuthorByID), id, expectedVersion, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		if err := r.checkVersionedWrite(result, expectedVersion); err != nil {
+			return err
+		}
+
+		if err := insertAuthorVersion(ctx, tx, id, constants.AuthorOperationDelete, 0, actor); err != nil {
+			return err
+		}
+
+		return insertAuthorEvent(ctx, tx, id, constants.AuthorEventDeleted)
+	})
+	if err != nil {
+		r.Logger.Error("author::DeleteAuthorByID - failed to delete author by id: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	r.Cache.InvalidateAuthor(ctx, id)
+
+	return nil
+}
+
+func (r *AuthorRepository) RestoreAuthorByID(ctx context.Context, id string, actor models.TokenData) error {
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, tx.Rebind(queryRestoreAuthorByID), id)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return domain.NotFound(constants.ErrDeletedAuthorNotFound)
+		}
+
+		if err := insertAuthorVersion(ctx, tx, id, constants.AuthorOperationRestore, 0, actor); err != nil {
+			return err
+		}
+
+		return insertAuthorEvent(ctx, tx, id, constants.AuthorEventRestored)
+	})
+	if err != nil {
+		r.Logger.Error("author::RestoreAuthorByID - failed to restore author by id: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	r.Cache.InvalidateAuthor(ctx, id)
+
+	return nil
+}
+
+// FindDuplicateCandidates returns scored pairs of possibly duplicate
+// authors, best first. Without an author filter each pair is listed once.
+func (r *AuthorRepository) FindDuplicateCandidates(ctx context.Context, filter models.AuthorDuplicateFilter) ([]models.AuthorDuplicate, int, error) {
+	var (
+		res       = make([]models.AuthorDuplicate, 0)
+		condition = " AND a.id < b.id"
+		args      []any
+	)
+
+	if filter.AuthorID != "" {
+		condition = " AND a.id = ?"
+		args = append(args, filter.AuthorID)
 	}
 
-	dataToCache, err := json.Marshal(res)
+	query := fmt.Sprintf(queryFindDuplicateCandidates, condition)
+	args = append(args, filter.MinScore, filter.Limit, filter.Offset)
+
+	err := r.DB.SelectContext(ctx, &res, r.DB.Rebind(query), args...)
+	if err != nil {
+		r.Logger.Error("author::FindDuplicateCandidates - failed to find duplicate candidates: ", err)
+		return nil, 0, domain.FromDatabase(err)
+	}
+
+	total := 0
+	if len(res) > 0 {
+		total = res[0].TotalItems
+	}
+
+	return res, total, nil
+}
+
+// MergeAuthors folds source into target in one transaction. The target
+// takes the fields it is missing from the source, the source is
+// soft-deleted and its ID redirects to the target from then on. Both get a
+// merge version; the target emits an update and the source a merge event.
+func (r *AuthorRepository) MergeAuthors(ctx context.Context, sourceID, targetID string, actor models.TokenData) (*models.Author, error) {
+	target := new(models.Author)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var locked []string
+		err := tx.SelectContext(ctx, &locked, tx.Rebind(queryLockMergeAuthors), sourceID, targetID)
+		if err != nil {
+			return err
+		}
+
+		if len(locked) < 2 {
+			notFound := domain.NotFound(constants.ErrAuthorNotFound)
+			for field, id := range map[string]string{"source_id": sourceID, "target_id": targetID} {
+				if !slices.ContainsFunc(locked, func(lockedID string) bool { return strings.EqualFold(lockedID, id) }) {
+					notFound.WithField(field, constants.ErrAuthorNotFound)
+				}
+			}
+			return notFound
+		}
+
+		err = tx.GetContext(ctx, target, tx.Rebind(queryMergeIntoTarget), targetID, sourceID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind(queryMergeSource), sourceID); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertAuthorRedirect), sourceID, targetID, actor.UserID, actor.Username)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind(queryRepointAuthorRedirects), targetID, sourceID); err != nil {
+			return err
+		}
+
+		for _, id := range []string{targetID, sourceID} {
+			if err := insertAuthorVersion(ctx, tx, id, constants.AuthorOperationMerge, 0, actor); err != nil {
+				return err
+			}
+		}
+
+		if err := insertAuthorEvent(ctx, tx, targetID, constants.AuthorEventUpdated); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertAuthorMergedEvent), constants.AuthorEventMerged, sourceID)
+		return err
+	})
+	if err != nil {
+		r.Logger.Error("author::MergeAuthors - failed to merge authors: ", err)
+		return nil, domain.FromDatabase(err)
+	}
+
+	r.Cache.InvalidateAuthor(ctx, sourceID)
+	r.Cache.InvalidateAuthor(ctx, targetID)
+
+	return target, nil
+}
+
+// FindAuthorRedirect returns the author that id was merged into.
+func (r *AuthorRepository) FindAuthorRedirect(ctx context.Context, id string) (string, error) {
+	var targetID string
+
+	err := r.DB.GetContext(ctx, &targetID, r.DB.Rebind(queryFindAuthorRedirect), id)
 	if err != nil {
-		r.Logger.Warn("category::FindAllAuthor - Failed to marshal data for caching: ", err)
-	} else {
-		err = r.Redis.Set(ctx, cacheKey, dataToCache, 5*time.Minute).Err()
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.NotFound(constants.ErrAuthorNotFound)
+		}
+
+		r.Logger.Error("author::FindAuthorRedirect - failed to find author redirect: ", err)
+		return "", domain.FromDatabase(err)
+	}
+
+	return targetID, nil
+}
+
+func (r *AuthorRepository) PurgeDeletedAuthors(ctx context.Context, retention time.Duration) (int64, error) {
+	var affected int64
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, tx.Rebind(queryPurgeDeletedAuthors), retention.Seconds(), constants.AuthorEventPurged)
 		if err != nil {
-			r.Logger.Warn("category::FindAllAuthor - Failed to cache data: ", err)
+			return err
 		}
+
+		affected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		r.Logger.Error("author::PurgeDeletedAuthors - failed to purge deleted authors: ", err)
+		return 0, domain.FromDatabase(err)
+	}
+
+	if affected > 0 {
+		r.Cache.InvalidateList(ctx)
+	}
+
+	return affected, nil
+}
+
+func (r *AuthorRepository) FindAuthorVersions(ctx context.Context, id string, limit, offset int) ([]models.AuthorVersion, int, error) {
+	var (
+		res   = make([]models.AuthorVersion, 0)
+		total int
+	)
+
+	err := r.DB.GetContext(ctx, &total, r.DB.Rebind(queryCountAuthorVersions), id)
+	if err != nil {
+		r.Logger.Error("author::FindAuthorVersions - failed to count author versions: ", err)
+		return nil, 0, domain.FromDatabase(err)
+	}
+
+	err = r.DB.SelectContext(ctx, &res, r.DB.Rebind(queryFindAuthorVersions), id, limit, offset)
+	if err != nil {
+		r.Logger.Error("author::FindAuthorVersions - failed to find author versions: ", err)
+		return nil, 0, domain.FromDatabase(err)
+	}
+
+	return res, total, nil
+}
+
+func (r *AuthorRepository) FindAuthorVersion(ctx context.Context, id string, version int) (*models.AuthorVersion, error) {
+	var (
+		res = new(models.AuthorVersion)
+	)
+
+	err := r.DB.GetContext(ctx, res, r.DB.Rebind(queryFindAuthorVersion), id, version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			r.Logger.Error("author::FindAuthorVersion - author version doesnt exist")
+			return nil, domain.NotFound(constants.ErrAuthorVersionNotFound)
+		}
+
+		r.Logger.Error("author::FindAuthorVersion - failed to find author version: ", err)
+		return nil, domain.FromDatabase(err)
 	}
 
 	return res, nil
 }
 
-func (r *AuthorRepository) UpdateNewAuthor(ctx context.Context, author *models.Author) error {
-	_, err := r.DB.ExecContext(ctx, r.DB.Rebind(queryUpdateNewAuthor),
-		author.Name,
-		author.Bio,
-		author.BirthDate,
-		author.DeathDate,
-		author.ID,
+// RelayAuthorEvents sequences newly committed outbox events, hands pending
+// ones to publish in sequence order and marks the delivered ones as
+// published. It stops at the first failure so later events of the same
+// author are never delivered ahead of it. Only one relay holds the lock at a
+// time; others return immediately. Change feeds read sequenced events only,
+// so they lag writes by up to one relay interval.
+func (r *AuthorRepository) RelayAuthorEvents(ctx context.Context, limit int, publish func(context.Context, models.AuthorEvent) error) (int, error) {
+	var (
+		published  = make([]int64, 0, limit)
+		publishErr error
 	)
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var locked bool
+		if err := tx.GetContext(ctx, &locked, tx.Rebind(queryLockAuthorEventRelay), authorEventRelayLockKey); err != nil {
+			return err
+		}
+
+		if !locked {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind(querySequenceAuthorEvents)); err != nil {
+			return err
+		}
+
+		events := make([]models.AuthorEvent, 0, limit)
+		if err := tx.SelectContext(ctx, &events, tx.Rebind(queryFindPendingAuthorEvents), limit); err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if publishErr = publish(ctx, event); publishErr != nil {
+				break
+			}
+			published = append(published, event.ID)
+		}
+
+		if len(published) == 0 {
+			return nil
+		}
+
+		_, err := tx.ExecContext(ctx, tx.Rebind(queryMarkAuthorEventsPublished), pq.Array(published))
+		return err
+	})
+	if err != nil {
+		r.Logger.Error("author::RelayAuthorEvents - failed to relay author events: ", err)
+		return 0, domain.FromDatabase(err)
+	}
+
+	return len(published), publishErr
+}
+
+// withTx runs fn in a transaction that is committed only when fn succeeds.
+func (r *AuthorRepository) withTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
 	if err != nil {
-		r.Logger.Error("author::UpdateNewAuthor - failed to update new author: ", err)
 		return err
 	}
 
-	return nil
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			r.Logger.Error("author::withTx - failed to rollback transaction: ", rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (r *AuthorRepository) DeleteAuthorByID(ctx context.Context, id string) error {
-	_, err := r.DB.ExecContext(ctx, r.DB.Rebind(queryDeleteAuthorByID), id)
+// insertAuthorVersion snapshots the current row of the author as its next
+// version. It must run in the transaction of the write it records, so the
+// snapshot is exactly that write. revertedFrom is only set for reverts and
+// is stored as NULL when 0.
+func insertAuthorVersion(ctx context.Context, tx *sqlx.Tx, id, operation string, revertedFrom int, actor models.TokenData) error {
+	_, err := tx.ExecContext(ctx, tx.Rebind(queryInsertAuthorVersion),
+		operation,
+		sql.NullInt64{Int64: int64(revertedFrom), Valid: revertedFrom > 0},
+		actor.UserID,
+		actor.Username,
+		id,
+	)
+	return err
+}
+
+// insertAuthorEvent writes an outbox row carrying the current state of the
+// author and queues its webhook deliveries. It must run in the transaction
+// of the write it describes.
+func insertAuthorEvent(ctx context.Context, tx *sqlx.Tx, id, eventType string) error {
+	_, err := tx.ExecContext(ctx, tx.Rebind(queryInsertAuthorEvent), eventType, id)
+	return err
+}
+
+func (r *AuthorRepository) FindAuthorEventsAfter(ctx context.Context, afterSequence int64, limit int) ([]models.AuthorEvent, error) {
+	res := make([]models.AuthorEvent, 0, limit)
+
+	err := r.DB.SelectContext(ctx, &res, r.DB.Rebind(queryFindAuthorEventsAfter), afterSequence, limit)
+	if err != nil {
+		r.Logger.Error("author::FindAuthorEventsAfter - failed to find author events: ", err)
+		return nil, domain.FromDatabase(err)
+	}
+
+	return res, nil
+}
+
+func (r *AuthorRepository) FindLatestAuthorEventSequence(ctx context.Context) (int64, error) {
+	var res int64
+
+	err := r.DB.GetContext(ctx, &res, r.DB.Rebind(queryFindLatestAuthorEventSequence))
+	if err != nil {
+		r.Logger.Error("author::FindLatestAuthorEventSequence - failed to find latest author event sequence: ", err)
+		return 0, domain.FromDatabase(err)
+	}
+
+	return res, nil
+}
+
+func (r *AuthorRepository) checkVersionedWrite(result sql.Result, expectedVersion int) error {
+	affected, err := result.RowsAffected()
 	if err != nil {
-		r.Logger.Error("author::DeleteAuthorByID - failed to delete author by id: ", err)
 		return err
 	}
 
+	if affected == 0 {
+		return versionedWriteError(expectedVersion)
+	}
+
 	return nil
 }
+
+// versionedWriteError reports why a versioned UPDATE touched no row: a stale
+// expected version when one was given, otherwise a missing author.
+func versionedWriteError(expectedVersion int) error {
+	if expectedVersion > 0 {
+		return domain.PreconditionFailed(constants.ErrAuthorVersionMismatch)
+	}
+
+	return domain.NotFound(constants.ErrAuthorNotFound)
+}
+
+// filterCacheKey encodes every field of filter so each distinct filter set
+// is cached under its own key.
+func filterCacheKey(filter models.AuthorFilter) string {
+	isLiving := "any"
+	if filter.IsLiving != nil {
+		isLiving = strconv.FormatBool(*filter.IsLiving)
+	}
+
+	cursor := ""
+	if filter.Cursor != nil {
+		cursor = fmt.Sprintf("%s,%s,%t,%t", filter.Cursor.UpdatedAt, filter.Cursor.ID, filter.Cursor.Backward, filter.Cursor.Desc)
+	}
+
+	return fmt.Sprintf("deleted=%t:name=%s:born=%s..%s:died=%s..%s:living=%s:sort=%s:desc=%t:cursor=%s:limit=%d:offset=%d",
+		filter.IncludeDeleted,
+		url.QueryEscape(strings.ToLower(filter.Name)),
+		formatFilterDate(filter.BornAfter),
+		formatFilterDate(filter.BornBefore),
+		formatFilterDate(filter.DiedAfter),
+		formatFilterDate(filter.DiedBefore),
+		isLiving,
+		filter.SortBy,
+		filter.SortDesc,
+		url.QueryEscape(cursor),
+		filter.Limit,
+		filter.Offset,
+	)
+}
+
+func formatFilterDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(constants.DateTimeFormat)
+}
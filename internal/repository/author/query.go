@@ -1,5 +1,12 @@
 package author
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+)
+
 const (
 	queryInsertNewAuthor = `
 		INSERT INTO authors
@@ -8,6 +15,29 @@ const (
 			bio,
 			birth_date
 		) VALUES (?, ?, ?)
+		RETURNING id, version, created_at, updated_at
+	`
+
+	queryInsertImportedAuthor = `
+		INSERT INTO authors
+		(
+			name,
+			bio,
+			birth_date,
+			death_date
+		) VALUES (?, ?, ?, ?)
+		RETURNING id, version, created_at, updated_at
+	`
+
+	queryFindExistingAuthorIdentities = `
+		SELECT DISTINCT
+			LOWER(a.name) AS name,
+			a.birth_date
+		FROM authors a
+		JOIN UNNEST(?::TEXT[], ?::DATE[]) AS i(name, birth_date)
+			ON LOWER(a.name) = LOWER(i.name)
+			AND a.birth_date = i.birth_date
+		WHERE a.deleted_at IS NULL
 	`
 
 	queryFindAuthorByID = `
@@ -16,9 +46,28 @@ const (
 			name,
 			bio,
 			birth_date,
-			death_date
+			death_date,
+			version,
+			created_at,
+			updated_at
 		FROM authors
 		WHERE id = ?
+			AND deleted_at IS NULL
+	`
+
+	queryFindAuthorsByIDs = `
+		SELECT
+			id,
+			name,
+			bio,
+			birth_date,
+			death_date,
+			version,
+			created_at,
+			updated_at
+		FROM authors
+		WHERE id = ANY(?)
+			AND deleted_at IS NULL
 	`
 
 	queryFindAllAuthor = `
@@ -27,9 +76,49 @@ const (
 			name,
 			bio,
 			birth_date,
-			death_date
+			death_date,
+			version,
+			created_at,
+			updated_at,
+			deleted_at
 		FROM authors
-		ORDER BY updated_at DESC
+	`
+
+	queryCountAuthor = `
+		SELECT COUNT(*) FROM authors
+	`
+
+	// queryDeclareAuthorExportCursor is followed by queryFindAllAuthor and
+	// the export filter.
+	queryDeclareAuthorExportCursor = `
+		DECLARE author_export NO SCROLL CURSOR FOR
+	`
+
+	queryFetchAuthorExportCursor = `
+		FETCH FORWARD %d FROM author_export
+	`
+
+	querySearchAuthors = `
+		WITH search AS (
+			SELECT websearch_to_tsquery('simple', ?) AS query, ?::TEXT AS term
+		)
+		SELECT
+			a.id,
+			a.name,
+			a.bio,
+			a.birth_date,
+			a.death_date,
+			a.version,
+			a.created_at,
+			a.updated_at,
+			ts_rank(a.search_vector, s.query) + word_similarity(s.term, a.name) AS rank,
+			ts_headline('simple', a.name, s.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS name_highlight,
+			ts_headline('simple', coalesce(a.bio, ''), s.query, 'StartSel=<mark>, StopSel=</mark>, MaxWords=20, MinWords=5, MaxFragments=2') AS bio_snippet,
+			COUNT(*) OVER() AS total_items
+		FROM authors a, search s
+		WHERE (a.search_vector @@ s.query OR s.term <% a.name)
+			AND a.deleted_at IS NULL
+		ORDER BY rank DESC, a.name ASC
 		LIMIT ?
 		OFFSET ?
 	`
@@ -41,11 +130,470 @@ const (
 			bio = ?,
 			birth_date = ?,
 			death_date = ?,
+			version = version + 1,
 			updated_at = NOW()
 		WHERE id = ?
+			AND deleted_at IS NULL
+			AND (? = 0 OR version = ?)
+		RETURNING version, updated_at
 	`
 
 	queryDeleteAuthorByID = `
-		DELETE FROM authors WHERE id = ?
+		UPDATE authors
+		SET
+			deleted_at = NOW(),
+			version = version + 1,
+			updated_at = NOW()
+		WHERE id = ?
+			AND deleted_at IS NULL
+			AND (? = 0 OR version = ?)
+	`
+
+	// queryRestoreAuthorByID never restores an author that was merged away;
+	// its ID redirects to the merge target instead.
+	queryRestoreAuthorByID = `
+		UPDATE authors
+		SET
+			deleted_at = NULL,
+			version = version + 1,
+			updated_at = NOW()
+		WHERE id = ?
+			AND deleted_at IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM author_redirects r WHERE r.old_id = authors.id)
+	`
+
+	// queryFindDuplicateCandidates scores pairs of active authors whose
+	// normalized names are trigram-similar. Name similarity weighs 0.6 and
+	// the birth and death dates 0.25 and 0.15, where an exact date counts in
+	// full and the same year or a missing date counts half. It is a format
+	// string taking the extra conditions on the pairs.
+	queryFindDuplicateCandidates = `
+		WITH pairs AS (
+			SELECT
+				a.id AS author_id,
+				b.id AS duplicate_id,
+				similarity(a.name_key, b.name_key) AS name_score,
+				CASE
+					WHEN a.birth_date = b.birth_date THEN 1
+					WHEN a.birth_date IS NULL OR b.birth_date IS NULL
+						OR date_part('year', a.birth_date) = date_part('year', b.birth_date) THEN 0.5
+					ELSE 0
+				END AS birth_score,
+				CASE
+					WHEN a.death_date IS NOT DISTINCT FROM b.death_date THEN 1
+					WHEN a.death_date IS NULL OR b.death_date IS NULL
+						OR date_part('year', a.death_date) = date_part('year', b.death_date) THEN 0.5
+					ELSE 0
+				END AS death_score
+			FROM authors a
+			JOIN authors b ON b.name_key %% a.name_key
+				AND b.id <> a.id
+				AND b.deleted_at IS NULL
+			WHERE a.deleted_at IS NULL%s
+		), scored AS (
+			SELECT
+				author_id,
+				duplicate_id,
+				name_score::FLOAT8 AS name_score,
+				ROUND((0.6 * name_score + 0.25 * birth_score + 0.15 * death_score)::NUMERIC, 4)::FLOAT8 AS score
+			FROM pairs
+		)
+		SELECT
+			s.name_score,
+			s.score,
+			a.id AS "author.id",
+			a.name AS "author.name",
+			COALESCE(a.bio, '') AS "author.bio",
+			a.birth_date AS "author.birth_date",
+			a.death_date AS "author.death_date",
+			a.version AS "author.version",
+			a.created_at AS "author.created_at",
+			a.updated_at AS "author.updated_at",
+			d.id AS "duplicate.id",
+			d.name AS "duplicate.name",
+			COALESCE(d.bio, '') AS "duplicate.bio",
+			d.birth_date AS "duplicate.birth_date",
+			d.death_date AS "duplicate.death_date",
+			d.version AS "duplicate.version",
+			d.created_at AS "duplicate.created_at",
+			d.updated_at AS "duplicate.updated_at",
+			COUNT(*) OVER() AS total_items
+		FROM scored s
+		JOIN authors a ON a.id = s.author_id
+		JOIN authors d ON d.id = s.duplicate_id
+		WHERE s.score >= ?
+		ORDER BY s.score DESC, a.id, d.id
+		LIMIT ?
+		OFFSET ?
+	`
+
+	queryLockMergeAuthors = `
+		SELECT id
+		FROM authors
+		WHERE id IN (?, ?)
+			AND deleted_at IS NULL
+		ORDER BY id
+		FOR UPDATE
+	`
+
+	// queryMergeIntoTarget fills the fields the target is missing from the
+	// source. Everything the target already has wins.
+	queryMergeIntoTarget = `
+		UPDATE authors t
+		SET
+			bio = COALESCE(NULLIF(t.bio, ''), s.bio),
+			death_date = COALESCE(t.death_date, s.death_date),
+			version = t.version + 1,
+			updated_at = NOW()
+		FROM authors s
+		WHERE t.id = ?
+			AND s.id = ?
+		RETURNING
+			t.id,
+			t.name,
+			COALESCE(t.bio, '') AS bio,
+			t.birth_date,
+			t.death_date,
+			t.version,
+			t.created_at,
+			t.updated_at
+	`
+
+	queryMergeSource = `
+		UPDATE authors
+		SET
+			deleted_at = NOW(),
+			version = version + 1,
+			updated_at = NOW()
+		WHERE id = ?
+	`
+
+	queryInsertAuthorRedirect = `
+		INSERT INTO author_redirects
+		(
+			old_id,
+			target_id,
+			merged_by,
+			merged_by_username
+		) VALUES (?, ?, NULLIF(?, ''), NULLIF(?, ''))
+	`
+
+	// queryRepointAuthorRedirects keeps redirects one hop long when a merge
+	// target is itself merged away.
+	queryRepointAuthorRedirects = `
+		UPDATE author_redirects
+		SET target_id = ?
+		WHERE target_id = ?
+	`
+
+	queryFindAuthorRedirect = `
+		SELECT target_id
+		FROM author_redirects
+		WHERE old_id = ?
+	`
+
+	queryInsertAuthorVersion = `
+		INSERT INTO author_versions
+		(
+			author_id,
+			version,
+			operation,
+			name,
+			bio,
+			birth_date,
+			death_date,
+			deleted_at,
+			reverted_from,
+			changed_by,
+			changed_by_username
+		)
+		SELECT
+			a.id,
+			a.version,
+			?,
+			a.name,
+			a.bio,
+			a.birth_date,
+			a.death_date,
+			a.deleted_at,
+			?,
+			NULLIF(?, ''),
+			NULLIF(?, '')
+		FROM authors a
+		WHERE a.id = ?
+	`
+
+	queryFindAuthorVersions = `
+		SELECT
+			id,
+			author_id,
+			version,
+			operation,
+			name,
+			COALESCE(bio, '') AS bio,
+			birth_date,
+			death_date,
+			deleted_at,
+			reverted_from,
+			COALESCE(changed_by, '') AS changed_by,
+			COALESCE(changed_by_username, '') AS changed_by_username,
+			changed_at
+		FROM author_versions
+		WHERE author_id = ?
+		ORDER BY version DESC
+		LIMIT ?
+		OFFSET ?
+	`
+
+	queryCountAuthorVersions = `
+		SELECT COUNT(*) FROM author_versions WHERE author_id = ?
+	`
+
+	queryFindAuthorVersion = `
+		SELECT
+			id,
+			author_id,
+			version,
+			operation,
+			name,
+			COALESCE(bio, '') AS bio,
+			birth_date,
+			death_date,
+			deleted_at,
+			reverted_from,
+			COALESCE(changed_by, '') AS changed_by,
+			COALESCE(changed_by_username, '') AS changed_by_username,
+			changed_at
+		FROM author_versions
+		WHERE author_id = ?
+			AND version = ?
+	`
+
+	// queryPurgeDeletedAuthors hard-deletes expired authors and writes their
+	// purge events in the same statement.
+	queryPurgeDeletedAuthors = `
+		WITH purged AS (
+			DELETE FROM authors
+			WHERE deleted_at IS NOT NULL
+				AND deleted_at < NOW() - make_interval(secs => ?)
+			RETURNING *
+		)
+		INSERT INTO author_events (author_id, event_type, payload)
+		SELECT a.id, ?, ` + authorEventPayload + `
+		FROM purged a
+	`
+
+	// queryInsertAuthorEvent writes the outbox row and queues its webhook
+	// deliveries in one statement, so both commit with the write.
+	queryInsertAuthorEvent = `
+		WITH event AS (
+			INSERT INTO author_events (author_id, event_type, payload)
+			SELECT a.id, ?, ` + authorEventPayload + `
+			FROM authors a
+			WHERE a.id = ?
+			RETURNING author_id, event_type, payload
+		)
+		` + authorEventWebhookDeliveries
+
+	// queryInsertAuthorMergedEvent adds the merge target to the payload of
+	// the merged author.
+	queryInsertAuthorMergedEvent = `
+		WITH event AS (
+			INSERT INTO author_events (author_id, event_type, payload)
+			SELECT a.id, ?, ` + authorEventPayload + ` || jsonb_build_object('merged_into', r.target_id)
+			FROM authors a
+			JOIN author_redirects r ON r.old_id = a.id
+			WHERE a.id = ?
+			RETURNING author_id, event_type, payload
+		)
+		` + authorEventWebhookDeliveries
+
+	queryFindAuthorEventsAfter = `
+		SELECT
+			id,
+			sequence,
+			author_id,
+			event_type,
+			payload,
+			created_at
+		FROM author_events
+		WHERE sequence > ?
+		ORDER BY sequence
+		LIMIT ?
+	`
+
+	queryFindLatestAuthorEventSequence = `
+		SELECT COALESCE(MAX(sequence), 0) FROM author_events
+	`
+
+	queryLockAuthorEventRelay = `
+		SELECT pg_try_advisory_xact_lock(?)
+	`
+
+	// querySequenceAuthorEvents numbers the committed events that have no
+	// sequence yet, continuing after the highest one. Only the relay runs it,
+	// under its lock, so events are numbered in the order they become
+	// visible rather than the order their ids were drawn.
+	querySequenceAuthorEvents = `
+		UPDATE author_events e
+		SET sequence = n.sequence
+		FROM (
+			SELECT
+				id,
+				(SELECT COALESCE(MAX(sequence), 0) FROM author_events) + ROW_NUMBER() OVER (ORDER BY id) AS sequence
+			FROM author_events
+			WHERE sequence IS NULL
+		) n
+		WHERE e.id = n.id
+	`
+
+	queryFindPendingAuthorEvents = `
+		SELECT
+			id,
+			sequence,
+			author_id,
+			event_type,
+			payload,
+			created_at
+		FROM author_events
+		WHERE published_at IS NULL
+			AND sequence IS NOT NULL
+		ORDER BY sequence
+		LIMIT ?
+	`
+
+	queryMarkAuthorEventsPublished = `
+		UPDATE author_events
+		SET published_at = NOW()
+		WHERE id = ANY(?)
+	`
+
+	authorEventPayload = `jsonb_build_object(
+			'id', a.id,
+			'name', a.name,
+			'bio', a.bio,
+			'birth_date', a.birth_date,
+			'death_date', a.death_date,
+			'version', a.version,
+			'created_at', a.created_at,
+			'updated_at', a.updated_at,
+			'deleted_at', a.deleted_at
+		)`
+
+	// authorEventWebhookDeliveries fans the event CTE out to every active
+	// webhook subscription listening for it. merged_into is always present
+	// in webhook payloads and null unless the author was merged.
+	authorEventWebhookDeliveries = `
+		INSERT INTO webhook_deliveries (subscription_id, event_type, author_id, payload)
+		SELECT
+			s.id,
+			e.event_type,
+			e.author_id,
+			e.payload || jsonb_build_object('merged_into', e.payload->'merged_into')
+		FROM event e
+		JOIN webhook_subscriptions s ON s.is_active
+			AND e.event_type = ANY(s.event_types)
 	`
 )
+
+const (
+	// authorEventRelayLockKey is the advisory lock held by the outbox relay
+	// so that only one instance publishes at a time and events keep their
+	// order.
+	authorEventRelayLockKey = 72170013
+
+	// authorExportFetchSize is the number of rows fetched per round trip
+	// from the export cursor.
+	authorExportFetchSize = 500
+)
+
+var authorSortColumns = map[string]string{
+	"name":       "name",
+	"birth_date": "birth_date",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// buildAuthorFilter renders the WHERE and ORDER BY clauses for filter. Sort
+// columns come from authorSortColumns only, never from user input directly.
+// The keyset condition of a cursor is left to buildAuthorCursor so the same
+// WHERE clause can be reused for counting.
+func buildAuthorFilter(filter models.AuthorFilter) (where string, orderBy string, args []any) {
+	var conditions []string
+
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	if filter.Name != "" {
+		conditions = append(conditions, "name ILIKE ?")
+		args = append(args, escapeLike(filter.Name)+"%")
+	}
+	if !filter.BornAfter.IsZero() {
+		conditions = append(conditions, "birth_date >= ?")
+		args = append(args, filter.BornAfter)
+	}
+	if !filter.BornBefore.IsZero() {
+		conditions = append(conditions, "birth_date <= ?")
+		args = append(args, filter.BornBefore)
+	}
+	if !filter.DiedAfter.IsZero() {
+		conditions = append(conditions, "death_date >= ?")
+		args = append(args, filter.DiedAfter)
+	}
+	if !filter.DiedBefore.IsZero() {
+		conditions = append(conditions, "death_date <= ?")
+		args = append(args, filter.DiedBefore)
+	}
+	if filter.IsLiving != nil {
+		if *filter.IsLiving {
+			conditions = append(conditions, "death_date IS NULL")
+		} else {
+			conditions = append(conditions, "death_date IS NOT NULL")
+		}
+	}
+
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	if filter.Cursor != nil {
+		return where, orderBy, args
+	}
+
+	column, ok := authorSortColumns[filter.SortBy]
+	if !ok {
+		column = "updated_at"
+	}
+
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	orderBy = fmt.Sprintf(" ORDER BY %s %s NULLS LAST, id %s", column, direction, direction)
+
+	return where, orderBy, args
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// buildAuthorCursor appends the (updated_at, id) keyset seek for cursor to
+// where. Backward cursors are read in the opposite order and must be
+// reversed.
+func buildAuthorCursor(where string, cursor *models.AuthorCursor) (string, string) {
+	operator, direction := "<", "DESC"
+	if cursor.Desc == cursor.Backward {
+		operator, direction = ">", "ASC"
+	}
+
+	condition := fmt.Sprintf("(updated_at, id) %s (?::TIMESTAMP, ?::UUID)", operator)
+	if where == "" {
+		where = " WHERE " + condition
+	} else {
+		where += " AND " + condition
+	}
+
+	return where, fmt.Sprintf(" ORDER BY updated_at %s, id %s", direction, direction)
+}
@@ -0,0 +1,173 @@
+package importjob
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/types"
+	"github.com/sirupsen/logrus"
+)
+
+type ImportJobRepository struct {
+	DB     *sqlx.DB
+	Logger *logrus.Logger
+}
+
+func (r *ImportJobRepository) InsertImportJob(ctx context.Context, job *models.ImportJob) error {
+	err := r.DB.QueryRowxContext(ctx, r.DB.Rebind(queryInsertImportJob),
+		job.FileName,
+		job.Format,
+		job.DryRun,
+		job.Rows,
+		job.TotalRows,
+		job.CreatedByID,
+		job.CreatedByUsername,
+	).Scan(&job.ID, &job.Status, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		r.Logger.Error("importjob::InsertImportJob - failed to insert import job: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	return nil
+}
+
+func (r *ImportJobRepository) FindImportJobByID(ctx context.Context, id string) (*models.ImportJob, error) {
+	res := new(models.ImportJob)
+
+	err := r.DB.GetContext(ctx, res, r.DB.Rebind(queryFindImportJobByID), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NotFound(constants.ErrImportJobNotFound)
+		}
+
+		r.Logger.Error("importjob::FindImportJobByID - failed to find import job: ", err)
+		return nil, domain.FromDatabase(err)
+	}
+
+	return res, nil
+}
+
+// FindPendingImportJobIDs returns queued and running jobs, oldest first.
+func (r *ImportJobRepository) FindPendingImportJobIDs(ctx context.Context, limit int) ([]string, error) {
+	res := make([]string, 0, limit)
+
+	err := r.DB.SelectContext(ctx, &res, r.DB.Rebind(queryFindPendingImportJobIDs), limit)
+	if err != nil {
+		r.Logger.Error("importjob::FindPendingImportJobIDs - failed to find pending import jobs: ", err)
+		return nil, domain.FromDatabase(err)
+	}
+
+	return res, nil
+}
+
+// StartImportJob marks the job running and returns it with its rows. It
+// fails with not found once the job is cancelled or finished.
+func (r *ImportJobRepository) StartImportJob(ctx context.Context, id string) (*models.ImportJob, error) {
+	res := new(models.ImportJob)
+
+	err := r.DB.GetContext(ctx, res, r.DB.Rebind(queryStartImportJob), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NotFound(constants.ErrImportJobNotFound)
+		}
+
+		r.Logger.Error("importjob::StartImportJob - failed to start import job: ", err)
+		return nil, domain.FromDatabase(err)
+	}
+
+	return res, nil
+}
+
+// UpdateImportJobProgress adds progress to a running job. It fails with not
+// found once the job has been cancelled.
+func (r *ImportJobRepository) UpdateImportJobProgress(ctx context.Context, id string, progress models.ImportJobProgress) error {
+	rowErrors := progress.RowErrors
+	if rowErrors == nil {
+		rowErrors = make([]models.ImportJobRowError, 0)
+	}
+
+	rowErrorsJSON, err := json.Marshal(rowErrors)
+	if err != nil {
+		r.Logger.Error("importjob::UpdateImportJobProgress - failed to marshal row errors: ", err)
+		return err
+	}
+
+	result, err := r.DB.ExecContext(ctx, r.DB.Rebind(queryUpdateImportJobProgress),
+		progress.Processed,
+		progress.Created,
+		progress.Valid,
+		progress.Duplicates,
+		progress.Invalid,
+		progress.Failed,
+		types.JSONText(rowErrorsJSON),
+		id,
+	)
+	if err != nil {
+		r.Logger.Error("importjob::UpdateImportJobProgress - failed to update import job progress: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	return checkAffected(result, constants.ErrImportJobNotFound)
+}
+
+func (r *ImportJobRepository) FinishImportJob(ctx context.Context, id, status, errorMessage string) error {
+	result, err := r.DB.ExecContext(ctx, r.DB.Rebind(queryFinishImportJob), status, errorMessage, id)
+	if err != nil {
+		r.Logger.Error("importjob::FinishImportJob - failed to finish import job: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	return checkAffected(result, constants.ErrImportJobNotFound)
+}
+
+// CancelImportJob fails with not found when no queued or running job has id.
+func (r *ImportJobRepository) CancelImportJob(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, r.DB.Rebind(queryCancelImportJob), id)
+	if err != nil {
+		r.Logger.Error("importjob::CancelImportJob - failed to cancel import job: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	return checkAffected(result, constants.ErrImportJobNotFound)
+}
+
+func (r *ImportJobRepository) FindImportJobRowErrors(ctx context.Context, id string) ([]models.ImportJobRowError, error) {
+	var rowErrors types.JSONText
+
+	err := r.DB.GetContext(ctx, &rowErrors, r.DB.Rebind(queryFindImportJobRowErrors), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NotFound(constants.ErrImportJobNotFound)
+		}
+
+		r.Logger.Error("importjob::FindImportJobRowErrors - failed to find import job row errors: ", err)
+		return nil, domain.FromDatabase(err)
+	}
+
+	res := make([]models.ImportJobRowError, 0)
+	if err := rowErrors.Unmarshal(&res); err != nil {
+		r.Logger.Error("importjob::FindImportJobRowErrors - failed to unmarshal row errors: ", err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func checkAffected(result sql.Result, notFoundMsg string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.NotFound(notFoundMsg)
+	}
+
+	return nil
+}
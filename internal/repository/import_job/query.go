@@ -0,0 +1,104 @@
+package importjob
+
+const (
+	importJobColumns = `
+			id,
+			status,
+			file_name,
+			format,
+			dry_run,
+			total_rows,
+			processed_rows,
+			created_rows,
+			valid_rows,
+			duplicate_rows,
+			invalid_rows,
+			failed_rows,
+			COALESCE(error_message, '') AS error_message,
+			COALESCE(created_by_id, '') AS created_by_id,
+			COALESCE(created_by_username, '') AS created_by_username,
+			created_at,
+			updated_at,
+			started_at,
+			finished_at
+	`
+
+	queryInsertImportJob = `
+		INSERT INTO import_jobs
+		(
+			file_name,
+			format,
+			dry_run,
+			rows,
+			total_rows,
+			created_by_id,
+			created_by_username
+		) VALUES (?, ?, ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''))
+		RETURNING id, status, created_at, updated_at
+	`
+
+	queryFindImportJobByID = `
+		SELECT` + importJobColumns + `
+		FROM import_jobs
+		WHERE id = ?
+	`
+
+	queryFindPendingImportJobIDs = `
+		SELECT id
+		FROM import_jobs
+		WHERE status IN ('queued', 'running')
+		ORDER BY created_at, id
+		LIMIT ?
+	`
+
+	// queryStartImportJob also picks up running jobs so that a job whose
+	// worker died is resumed from its processed_rows by another worker.
+	queryStartImportJob = `
+		UPDATE import_jobs
+		SET
+			status = 'running',
+			started_at = COALESCE(started_at, CURRENT_TIMESTAMP),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status IN ('queued', 'running')
+		RETURNING` + importJobColumns + `, rows
+	`
+
+	queryUpdateImportJobProgress = `
+		UPDATE import_jobs
+		SET
+			processed_rows = processed_rows + ?,
+			created_rows = created_rows + ?,
+			valid_rows = valid_rows + ?,
+			duplicate_rows = duplicate_rows + ?,
+			invalid_rows = invalid_rows + ?,
+			failed_rows = failed_rows + ?,
+			row_errors = row_errors || ?::JSONB,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = 'running'
+	`
+
+	queryFinishImportJob = `
+		UPDATE import_jobs
+		SET
+			status = ?,
+			error_message = NULLIF(?, ''),
+			finished_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = 'running'
+	`
+
+	queryCancelImportJob = `
+		UPDATE import_jobs
+		SET
+			status = 'cancelled',
+			finished_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status IN ('queued', 'running')
+	`
+
+	queryFindImportJobRowErrors = `
+		SELECT row_errors
+		FROM import_jobs
+		WHERE id = ?
+	`
+)
@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"strings"
+
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+)
+
+const (
+	queryInsertWebhookSubscription = `
+		INSERT INTO webhook_subscriptions
+		(
+			url,
+			secret,
+			event_types,
+			created_by
+		) VALUES (?, ?, ?, NULLIF(?, ''))
+		RETURNING id, is_active, created_at, updated_at
+	`
+
+	queryFindWebhookSubscriptions = `
+		SELECT
+			id,
+			url,
+			secret,
+			event_types,
+			is_active,
+			COALESCE(created_by, '') AS created_by,
+			created_at,
+			updated_at
+		FROM webhook_subscriptions
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+		OFFSET ?
+	`
+
+	queryCountWebhookSubscriptions = `
+		SELECT COUNT(*) FROM webhook_subscriptions
+	`
+
+	queryDeleteWebhookSubscription = `
+		DELETE FROM webhook_subscriptions
+		WHERE id = ?
+	`
+
+	// queryClaimDueWebhookDeliveries leases due deliveries by pushing their
+	// next attempt into the future, so concurrent dispatchers skip them and
+	// a crashed dispatcher's claims are retried once the lease runs out.
+	queryClaimDueWebhookDeliveries = `
+		UPDATE webhook_deliveries d
+		SET
+			next_attempt_at = NOW() + make_interval(secs => ?),
+			updated_at = NOW()
+		FROM webhook_subscriptions s
+		WHERE s.id = d.subscription_id
+			AND d.id IN (
+				SELECT id
+				FROM webhook_deliveries
+				WHERE status = 'pending'
+					AND next_attempt_at <= NOW()
+				ORDER BY next_attempt_at, id
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING
+			d.id,
+			d.subscription_id,
+			d.event_type,
+			d.author_id,
+			d.payload,
+			d.status,
+			d.attempts,
+			d.next_attempt_at,
+			d.last_status_code,
+			COALESCE(d.last_error, '') AS last_error,
+			d.created_at,
+			d.delivered_at,
+			s.url,
+			s.secret
+	`
+
+	queryMarkWebhookDelivered = `
+		UPDATE webhook_deliveries
+		SET
+			status = 'delivered',
+			attempts = attempts + 1,
+			last_status_code = ?,
+			last_error = NULL,
+			delivered_at = NOW(),
+			updated_at = NOW()
+		WHERE id = ?
+	`
+
+	queryMarkWebhookFailed = `
+		UPDATE webhook_deliveries
+		SET
+			status = CASE WHEN attempts + 1 >= ? THEN 'dead' ELSE 'pending' END,
+			attempts = attempts + 1,
+			next_attempt_at = NOW() + make_interval(secs => ?),
+			last_status_code = ?,
+			last_error = ?,
+			updated_at = NOW()
+		WHERE id = ?
+	`
+
+	queryRetryWebhookDelivery = `
+		UPDATE webhook_deliveries
+		SET
+			status = 'pending',
+			attempts = 0,
+			next_attempt_at = NOW(),
+			updated_at = NOW()
+		WHERE id = ?
+			AND status = 'dead'
+	`
+
+	queryFindWebhookDeliveries = `
+		SELECT
+			id,
+			subscription_id,
+			event_type,
+			author_id,
+			payload,
+			status,
+			attempts,
+			next_attempt_at,
+			last_status_code,
+			COALESCE(last_error, '') AS last_error,
+			created_at,
+			delivered_at
+		FROM webhook_deliveries
+	`
+
+	queryCountWebhookDeliveries = `
+		SELECT COUNT(*) FROM webhook_deliveries
+	`
+)
+
+func buildDeliveryFilter(filter models.WebhookDeliveryFilter) (where string, args []any) {
+	var conditions []string
+
+	if filter.SubscriptionID != "" {
+		conditions = append(conditions, "subscription_id = ?")
+		args = append(args, filter.SubscriptionID)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return where, args
+}
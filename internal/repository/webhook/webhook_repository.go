@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+type WebhookRepository struct {
+	DB     *sqlx.DB
+	Logger *logrus.Logger
+}
+
+func (r *WebhookRepository) InsertSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	err := r.DB.QueryRowxContext(ctx, r.DB.Rebind(queryInsertWebhookSubscription),
+		subscription.URL,
+		subscription.Secret,
+		subscription.EventTypes,
+		subscription.CreatedBy,
+	).Scan(&subscription.ID, &subscription.IsActive, &subscription.CreatedAt, &subscription.UpdatedAt)
+	if err != nil {
+		r.Logger.Error("webhook::InsertSubscription - failed to insert webhook subscription: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) FindSubscriptions(ctx context.Context, limit, offset int) ([]models.WebhookSubscription, int, error) {
+	var (
+		res   = make([]models.WebhookSubscription, 0)
+		total int
+	)
+
+	err := r.DB.GetContext(ctx, &total, r.DB.Rebind(queryCountWebhookSubscriptions))
+	if err != nil {
+		r.Logger.Error("webhook::FindSubscriptions - failed to count webhook subscriptions: ", err)
+		return nil, 0, domain.FromDatabase(err)
+	}
+
+	err = r.DB.SelectContext(ctx, &res, r.DB.Rebind(queryFindWebhookSubscriptions), limit, offset)
+	if err != nil {
+		r.Logger.Error("webhook::FindSubscriptions - failed to find webhook subscriptions: ", err)
+		return nil, 0, domain.FromDatabase(err)
+	}
+
+	return res, total, nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, r.DB.Rebind(queryDeleteWebhookSubscription), id)
+	if err != nil {
+		r.Logger.Error("webhook::DeleteSubscription - failed to delete webhook subscription: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	return checkAffected(result, constants.ErrWebhookNotFound)
+}
+
+// ClaimDueDeliveries leases up to limit due deliveries for lease.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	res := make([]models.WebhookDelivery, 0, limit)
+
+	err := r.DB.SelectContext(ctx, &res, r.DB.Rebind(queryClaimDueWebhookDeliveries), lease.Seconds(), limit)
+	if err != nil {
+		r.Logger.Error("webhook::ClaimDueDeliveries - failed to claim webhook deliveries: ", err)
+		return nil, domain.FromDatabase(err)
+	}
+
+	return res, nil
+}
+
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id int64, statusCode int) error {
+	_, err := r.DB.ExecContext(ctx, r.DB.Rebind(queryMarkWebhookDelivered), statusCode, id)
+	if err != nil {
+		r.Logger.Error("webhook::MarkDelivered - failed to mark webhook delivery: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed attempt. The delivery is retried after backoff
+// or moved to the dead-letter list once it has used maxAttempts.
+func (r *WebhookRepository) MarkFailed(ctx context.Context, id int64, maxAttempts int, backoff time.Duration, statusCode int, lastError string) error {
+	_, err := r.DB.ExecContext(ctx, r.DB.Rebind(queryMarkWebhookFailed),
+		maxAttempts,
+		backoff.Seconds(),
+		sql.NullInt64{Int64: int64(statusCode), Valid: statusCode > 0},
+		lastError,
+		id,
+	)
+	if err != nil {
+		r.Logger.Error("webhook::MarkFailed - failed to mark webhook delivery: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) RetryDelivery(ctx context.Context, id int64) error {
+	result, err := r.DB.ExecContext(ctx, r.DB.Rebind(queryRetryWebhookDelivery), id)
+	if err != nil {
+		r.Logger.Error("webhook::RetryDelivery - failed to retry webhook delivery: ", err)
+		return domain.FromDatabase(err)
+	}
+
+	return checkAffected(result, constants.ErrDeadLetterNotFound)
+}
+
+func (r *WebhookRepository) FindDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter) ([]models.WebhookDelivery, int, error) {
+	var (
+		res   = make([]models.WebhookDelivery, 0)
+		total int
+	)
+
+	where, args := buildDeliveryFilter(filter)
+
+	err := r.DB.GetContext(ctx, &total, r.DB.Rebind(queryCountWebhookDeliveries+where), args...)
+	if err != nil {
+		r.Logger.Error("webhook::FindDeliveries - failed to count webhook deliveries: ", err)
+		return nil, 0, domain.FromDatabase(err)
+	}
+
+	query := queryFindWebhookDeliveries + where + " ORDER BY id DESC LIMIT ? OFFSET ?"
+	err = r.DB.SelectContext(ctx, &res, r.DB.Rebind(query), append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		r.Logger.Error("webhook::FindDeliveries - failed to find webhook deliveries: ", err)
+		return nil, 0, domain.FromDatabase(err)
+	}
+
+	return res, total, nil
+}
+
+func checkAffected(result sql.Result, notFoundMsg string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.NotFound(notFoundMsg)
+	}
+
+	return nil
+}
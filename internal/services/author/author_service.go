@@ -3,10 +3,12 @@ package author
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/hilmiikhsan/library-author-service/constants"
 	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
 	"github.com/hilmiikhsan/library-author-service/internal/dto"
 	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
 	"github.com/hilmiikhsan/library-author-service/internal/models"
@@ -15,31 +17,52 @@ import (
 
 type AuthorService struct {
 	AuthorRepo interfaces.IAuthorRepository
+	Notifier   interfaces.IEventNotifier
 	Logger     *logrus.Logger
 }
 
-func (s *AuthorService) CreateAuthor(ctx context.Context, req *dto.CreateAuthorRequest) error {
+func (s *AuthorService) CreateAuthor(ctx context.Context, req *dto.CreateAuthorRequest) (*dto.GetDetailAuthorResponse, error) {
 	birthDate, err := helpers.ParseDate(req.BirthDate, constants.DateTimeFormat)
 	if err != nil {
 		s.Logger.Error("author::CreateAuthor - failed to parse birth date: ", err)
-		return errors.New(constants.ErrInvalidFormatDate)
+		return nil, domain.InvalidDate("birth_date").WithCause(err)
 	}
 
-	err = s.AuthorRepo.InsertNewAuthor(ctx, &models.Author{
+	authorData := &models.Author{
 		Name:      req.Name,
 		Bio:       req.Bio,
 		BirthDate: birthDate,
-	})
+	}
+
+	actor, _ := helpers.TokenDataFromContext(ctx)
+	err = s.AuthorRepo.InsertNewAuthor(ctx, authorData, actor)
 	if err != nil {
 		s.Logger.Error("author::CreateAuthor - failed to insert new author: ", err)
-		return err
+		return nil, err
 	}
 
-	return nil
+	return &dto.GetDetailAuthorResponse{
+		ID:        authorData.ID.String(),
+		Name:      authorData.Name,
+		Bio:       authorData.Bio,
+		BirthDate: authorData.BirthDate.Format(constants.DateTimeFormat),
+		DeathDate: helpers.FormatNullableDate(authorData.DeathDate, constants.DateTimeFormat),
+		Version:   authorData.Version,
+		CreatedAt: authorData.CreatedAt.Format(constants.TimestampFormat),
+		UpdatedAt: authorData.UpdatedAt.Format(constants.TimestampFormat),
+	}, nil
 }
 
+// GetDetailAuthor follows the redirect of an author that was merged away,
+// so the returned ID differs from id in that case.
 func (s *AuthorService) GetDetailAuthor(ctx context.Context, id string) (*dto.GetDetailAuthorResponse, error) {
 	authorData, err := s.AuthorRepo.FindAuthorByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		targetID, redirectErr := s.AuthorRepo.FindAuthorRedirect(ctx, id)
+		if redirectErr == nil {
+			authorData, err = s.AuthorRepo.FindAuthorByID(ctx, targetID)
+		}
+	}
 	if err != nil {
 		s.Logger.Error("author::GetAuthorDetail - failed to find Author by id: ", err)
 		return nil, err
@@ -51,33 +74,152 @@ func (s *AuthorService) GetDetailAuthor(ctx context.Context, id string) (*dto.Ge
 		Bio:       authorData.Bio,
 		BirthDate: authorData.BirthDate.Format(constants.DateTimeFormat),
 		DeathDate: helpers.FormatNullableDate(authorData.DeathDate, constants.DateTimeFormat),
+		Version:   authorData.Version,
+		CreatedAt: authorData.CreatedAt.Format(constants.TimestampFormat),
+		UpdatedAt: authorData.UpdatedAt.Format(constants.TimestampFormat),
 	}, nil
 }
 
-func (s *AuthorService) GetListAuthor(ctx context.Context, limit, offset int) (*dto.GetListAuthorResponse, error) {
-	pageSize := limit
-	pageIndex := (offset - 1) * limit
+func (s *AuthorService) BatchGetAuthors(ctx context.Context, ids []string) (*dto.BatchGetAuthorsResponse, error) {
+	uniqueIDs := make([]string, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		id = strings.ToLower(id)
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		uniqueIDs = append(uniqueIDs, id)
+	}
 
-	authorData, err := s.AuthorRepo.FindAllAuthor(ctx, pageSize, pageIndex)
+	authorData, err := s.AuthorRepo.FindAuthorsByIDs(ctx, uniqueIDs)
+	if err != nil {
+		s.Logger.Error("author::BatchGetAuthors - failed to find Author by ids: ", err)
+		return nil, err
+	}
+
+	found := make(map[string]models.Author, len(authorData))
+	for _, author := range authorData {
+		found[author.ID.String()] = author
+	}
+
+	response := &dto.BatchGetAuthorsResponse{
+		AuthorList: make([]dto.Author, 0, len(found)),
+		MissingIDs: make([]string, 0),
+	}
+
+	for _, id := range uniqueIDs {
+		author, ok := found[id]
+		if !ok {
+			response.MissingIDs = append(response.MissingIDs, id)
+			continue
+		}
+
+		response.AuthorList = append(response.AuthorList, toAuthorDTO(author))
+	}
+
+	return response, nil
+}
+
+func (s *AuthorService) GetListAuthor(ctx context.Context, req *dto.GetListAuthorRequest) (*dto.GetListAuthorResponse, error) {
+	filter := models.AuthorFilter{
+		IncludeDeleted: req.IncludeDeleted,
+		Name:           strings.TrimSpace(req.Name),
+		IsLiving:       req.IsLiving,
+		SortBy:         req.Sort,
+		SortDesc:       req.Order == "desc" || (req.Order == "" && req.Sort == ""),
+		Limit:          req.Limit,
+		Offset:         (req.Page - 1) * req.Limit,
+	}
+
+	err := parseDateFilters(&filter, req.BornAfter, req.BornBefore, req.DiedAfter, req.DiedBefore)
+	if err != nil {
+		s.Logger.Error("author::GetListAuthor - failed to parse date filter: ", err)
+		return nil, err
+	}
+
+	// Cursors are keyed on (updated_at, id), so they are only offered when
+	// sorting by updated_at, in either direction.
+	keysetOrder := req.Sort == "" || req.Sort == "updated_at"
+	if req.Cursor != "" {
+		if !keysetOrder {
+			s.Logger.Error("author::GetListAuthor - cursor used with unsupported sort")
+			return nil, domain.Validation(constants.ErrInvalidCursor).WithField("cursor", constants.ErrCursorUnsupportedSort)
+		}
+
+		cursor, err := decodeCursor(req.Cursor)
+		if err != nil || !helpers.IsValidUUID(cursor.ID) {
+			s.Logger.Error("author::GetListAuthor - failed to decode cursor: ", err)
+			return nil, domain.Validation(constants.ErrInvalidCursor).WithField("cursor", constants.ErrInvalidCursor)
+		}
+
+		if _, err := time.Parse(cursorTimestampFormat, cursor.UpdatedAt); err != nil {
+			s.Logger.Error("author::GetListAuthor - failed to parse cursor timestamp: ", err)
+			return nil, domain.Validation(constants.ErrInvalidCursor).WithField("cursor", constants.ErrInvalidCursor)
+		}
+
+		if cursor.Desc != filter.SortDesc {
+			s.Logger.Error("author::GetListAuthor - cursor used with a different order")
+			return nil, domain.Validation(constants.ErrInvalidCursor).WithField("cursor", constants.ErrCursorOrderMismatch)
+		}
+
+		filter.Cursor = cursor
+		filter.Offset = 0
+		// Read one extra row to learn whether another page follows.
+		filter.Limit = req.Limit + 1
+	}
+
+	authorData, total, err := s.AuthorRepo.FindAllAuthor(ctx, filter)
 	if err != nil {
 		s.Logger.Error("author::GetListAuthor - failed to find all Author: ", err)
 		return nil, err
 	}
 
+	hasMore := false
+	if filter.Cursor != nil && len(authorData) > req.Limit {
+		hasMore = true
+		if filter.Cursor.Backward {
+			authorData = authorData[1:]
+		} else {
+			authorData = authorData[:req.Limit]
+		}
+	}
+
 	categories := make([]dto.Author, 0)
 	for _, author := range authorData {
-		categories = append(categories, dto.Author{
-			ID:        author.ID.String(),
-			Name:      author.Name,
-			Bio:       author.Bio,
-			BirthDate: author.BirthDate.Format(constants.DateTimeFormat),
-			DeathDate: helpers.FormatNullableDate(author.DeathDate, constants.DateTimeFormat),
-		})
+		categories = append(categories, toAuthorDTO(author))
 	}
 
 	pagination := dto.Pagination{
-		Page:  offset,
-		Limit: limit,
+		Limit:      req.Limit,
+		TotalItems: total,
+		TotalPages: (total + req.Limit - 1) / req.Limit,
+	}
+
+	if filter.Cursor == nil {
+		pagination.Page = req.Page
+	}
+
+	if keysetOrder && len(authorData) > 0 {
+		var hasNext, hasPrev bool
+		switch {
+		case filter.Cursor == nil:
+			hasNext = filter.Offset+len(authorData) < total
+			hasPrev = filter.Offset > 0
+		case filter.Cursor.Backward:
+			hasNext = true
+			hasPrev = hasMore
+		default:
+			hasNext = hasMore
+			hasPrev = true
+		}
+
+		if hasNext {
+			pagination.NextCursor = encodeCursor(authorData[len(authorData)-1], filter.SortDesc, false)
+		}
+		if hasPrev {
+			pagination.PrevCursor = encodeCursor(authorData[0], filter.SortDesc, true)
+		}
 	}
 
 	response := &dto.GetListAuthorResponse{
@@ -88,40 +230,74 @@ func (s *AuthorService) GetListAuthor(ctx context.Context, limit, offset int) (*
 	return response, nil
 }
 
+func (s *AuthorService) SearchAuthors(ctx context.Context, req *dto.SearchAuthorRequest) (*dto.SearchAuthorResponse, error) {
+	offset := (req.Page - 1) * req.Limit
+
+	searchData, err := s.AuthorRepo.SearchAuthors(ctx, strings.TrimSpace(req.Query), req.Limit, offset)
+	if err != nil {
+		s.Logger.Error("author::SearchAuthors - failed to search Author: ", err)
+		return nil, err
+	}
+
+	total := 0
+	results := make([]dto.AuthorSearchResult, 0, len(searchData))
+	for _, result := range searchData {
+		total = result.TotalItems
+		results = append(results, dto.AuthorSearchResult{
+			Author:        toAuthorDTO(result.Author),
+			Rank:          result.Rank,
+			NameHighlight: result.NameHighlight,
+			BioSnippet:    result.BioSnippet,
+		})
+	}
+
+	return &dto.SearchAuthorResponse{
+		AuthorList: results,
+		Pagination: dto.Pagination{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalItems: total,
+			TotalPages: (total + req.Limit - 1) / req.Limit,
+		},
+	}, nil
+}
+
 func (s *AuthorService) UpdateAuthor(ctx context.Context, req *dto.UpdateAuthorRequest) error {
+	if req.ExpectedVersion <= 0 {
+		s.Logger.Error("author::UpdateAuthor - missing expected version")
+		return domain.PreconditionRequired(constants.ErrIfMatchRequired)
+	}
+
 	authorData, err := s.AuthorRepo.FindAuthorByID(ctx, req.ID)
 	if err != nil {
 		s.Logger.Error("author::UpdateAuthor - failed to find Author by id: ", err)
 		return err
 	}
 
-	if len(authorData.Name) == 0 {
-		s.Logger.Error("author::UpdateAuthor - Author not found")
-		return errors.New(constants.ErrAuthorNotFound)
-	}
-
 	birthDate, err := helpers.ParseDate(req.BirthDate, constants.DateTimeFormat)
 	if err != nil {
 		s.Logger.Error("author::UpdateAuthor - failed to parse birth date: ", err)
-		return errors.New(constants.ErrInvalidFormatDate)
+		return domain.InvalidDate("birth_date").WithCause(err)
 	}
 
 	var deathDate time.Time
 	if req.DeathDate != "" {
 		deathDate, err = helpers.ParseDate(req.DeathDate, constants.DateTimeFormat)
 		if err != nil {
-			s.Logger.Error("author::UpdateAuthor - failed to parse birth date: ", err)
-			return errors.New(constants.ErrInvalidFormatDate)
+			s.Logger.Error("author::UpdateAuthor - failed to parse death date: ", err)
+			return domain.InvalidDate("death_date").WithCause(err)
 		}
 	}
 
+	actor, _ := helpers.TokenDataFromContext(ctx)
 	err = s.AuthorRepo.UpdateNewAuthor(ctx, &models.Author{
 		ID:        authorData.ID,
 		Name:      req.Name,
 		Bio:       req.Bio,
 		BirthDate: birthDate,
 		DeathDate: helpers.NullTimeScan(deathDate),
-	})
+		Version:   req.ExpectedVersion,
+	}, 0, actor)
 	if err != nil {
 		s.Logger.Error("author::UpdateAuthor - failed to update Author: ", err)
 		return err
@@ -130,23 +306,301 @@ func (s *AuthorService) UpdateAuthor(ctx context.Context, req *dto.UpdateAuthorR
 	return nil
 }
 
-func (s *AuthorService) DeleteAuthor(ctx context.Context, id string) error {
-	authorData, err := s.AuthorRepo.FindAuthorByID(ctx, id)
+// PatchAuthor applies a merge patch on top of the stored author. Only the
+// keys listed in req.Fields are touched.
+func (s *AuthorService) PatchAuthor(ctx context.Context, req *dto.PatchAuthorRequest) (*dto.GetDetailAuthorResponse, error) {
+	if req.ExpectedVersion <= 0 {
+		s.Logger.Error("author::PatchAuthor - missing expected version")
+		return nil, domain.PreconditionRequired(constants.ErrIfMatchRequired)
+	}
+
+	authorData, err := s.AuthorRepo.FindAuthorByID(ctx, req.ID)
+	if err != nil {
+		s.Logger.Error("author::PatchAuthor - failed to find Author by id: ", err)
+		return nil, err
+	}
+
+	// An empty merge patch changes nothing, so nothing is written; the
+	// precondition is still checked.
+	if len(req.Fields) == 0 {
+		if authorData.Version != req.ExpectedVersion {
+			s.Logger.Error("author::PatchAuthor - version mismatch")
+			return nil, domain.PreconditionFailed(constants.ErrAuthorVersionMismatch)
+		}
+
+		return toAuthorDetailResponse(*authorData), nil
+	}
+
+	for _, field := range req.Fields {
+		switch field {
+		case "name":
+			if req.Name == nil {
+				return nil, domain.Validation(constants.ErrFieldCannotBeNull).WithField(field, constants.ErrFieldCannotBeNull)
+			}
+			authorData.Name = *req.Name
+		case "bio":
+			authorData.Bio = ""
+			if req.Bio != nil {
+				authorData.Bio = *req.Bio
+			}
+		case "birth_date":
+			if req.BirthDate == nil {
+				return nil, domain.Validation(constants.ErrFieldCannotBeNull).WithField(field, constants.ErrFieldCannotBeNull)
+			}
+
+			birthDate, err := helpers.ParseDate(*req.BirthDate, constants.DateTimeFormat)
+			if err != nil {
+				s.Logger.Error("author::PatchAuthor - failed to parse birth date: ", err)
+				return nil, domain.InvalidDate(field).WithCause(err)
+			}
+			authorData.BirthDate = birthDate
+		case "death_date":
+			var deathDate time.Time
+			if req.DeathDate != nil {
+				deathDate, err = helpers.ParseDate(*req.DeathDate, constants.DateTimeFormat)
+				if err != nil {
+					s.Logger.Error("author::PatchAuthor - failed to parse death date: ", err)
+					return nil, domain.InvalidDate(field).WithCause(err)
+				}
+			}
+			authorData.DeathDate = helpers.NullTimeScan(deathDate)
+		default:
+			return nil, domain.Validation(constants.ErrUnknownPatchField).WithField(field, constants.ErrUnknownPatchField)
+		}
+	}
+
+	actor, _ := helpers.TokenDataFromContext(ctx)
+	authorData.Version = req.ExpectedVersion
+	err = s.AuthorRepo.UpdateNewAuthor(ctx, authorData, 0, actor)
+	if err != nil {
+		s.Logger.Error("author::PatchAuthor - failed to update Author: ", err)
+		return nil, err
+	}
+
+	return toAuthorDetailResponse(*authorData), nil
+}
+
+func (s *AuthorService) DeleteAuthor(ctx context.Context, id string, expectedVersion int) error {
+	if expectedVersion <= 0 {
+		s.Logger.Error("author::DeleteAuthor - missing expected version")
+		return domain.PreconditionRequired(constants.ErrIfMatchRequired)
+	}
+
+	_, err := s.AuthorRepo.FindAuthorByID(ctx, id)
 	if err != nil {
 		s.Logger.Error("author::DeleteAuthor - failed to find Author by id: ", err)
 		return err
 	}
 
-	if len(authorData.Name) == 0 {
-		s.Logger.Error("author::DeleteAuthor - Author not found")
-		return errors.New(constants.ErrAuthorNotFound)
+	actor, _ := helpers.TokenDataFromContext(ctx)
+	err = s.AuthorRepo.DeleteAuthorByID(ctx, id, expectedVersion, actor)
+	if err != nil {
+		s.Logger.Error("author::DeleteAuthor - failed to delete Author: ", err)
+		return err
 	}
 
-	err = s.AuthorRepo.DeleteAuthorByID(ctx, id)
+	return nil
+}
+
+// ExportAuthors calls send for every author matching req, in list order.
+// Authors are streamed from the database as send consumes them.
+func (s *AuthorService) ExportAuthors(ctx context.Context, req *dto.ExportAuthorsRequest, send func(dto.Author) error) error {
+	filter := models.AuthorFilter{
+		IncludeDeleted: req.IncludeDeleted,
+		Name:           strings.TrimSpace(req.Name),
+		IsLiving:       req.IsLiving,
+		SortBy:         req.Sort,
+		SortDesc:       req.Order == "desc" || (req.Order == "" && req.Sort == ""),
+	}
+
+	err := parseDateFilters(&filter, req.BornAfter, req.BornBefore, req.DiedAfter, req.DiedBefore)
 	if err != nil {
-		s.Logger.Error("author::DeleteAuthor - failed to delete Author: ", err)
+		s.Logger.Error("author::ExportAuthors - failed to parse date filter: ", err)
+		return err
+	}
+
+	err = s.AuthorRepo.StreamAuthors(ctx, filter, func(author models.Author) error {
+		return send(toAuthorDTO(author))
+	})
+	if err != nil {
+		s.Logger.Error("author::ExportAuthors - failed to stream Author: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// parseDateFilters sets the date bounds of filter from their request values.
+// Empty values leave the bound open.
+func parseDateFilters(filter *models.AuthorFilter, bornAfter, bornBefore, diedAfter, diedBefore string) error {
+	dateFilters := []struct {
+		field string
+		value string
+		dest  *time.Time
+	}{
+		{"born_after", bornAfter, &filter.BornAfter},
+		{"born_before", bornBefore, &filter.BornBefore},
+		{"died_after", diedAfter, &filter.DiedAfter},
+		{"died_before", diedBefore, &filter.DiedBefore},
+	}
+	for _, dateFilter := range dateFilters {
+		if dateFilter.value == "" {
+			continue
+		}
+
+		date, err := helpers.ParseDate(dateFilter.value, constants.DateTimeFormat)
+		if err != nil {
+			return domain.InvalidDate(dateFilter.field).WithCause(err)
+		}
+		*dateFilter.dest = date
+	}
+
+	return nil
+}
+
+func toAuthorDTO(author models.Author) dto.Author {
+	return dto.Author{
+		ID:        author.ID.String(),
+		Name:      author.Name,
+		Bio:       author.Bio,
+		BirthDate: author.BirthDate.Format(constants.DateTimeFormat),
+		DeathDate: helpers.FormatNullableDate(author.DeathDate, constants.DateTimeFormat),
+		Version:   author.Version,
+		CreatedAt: author.CreatedAt.Format(constants.TimestampFormat),
+		UpdatedAt: author.UpdatedAt.Format(constants.TimestampFormat),
+		DeletedAt: helpers.FormatNullableDate(author.DeletedAt, constants.TimestampFormat),
+	}
+}
+
+func (s *AuthorService) RestoreAuthor(ctx context.Context, id string) error {
+	actor, _ := helpers.TokenDataFromContext(ctx)
+	err := s.AuthorRepo.RestoreAuthorByID(ctx, id, actor)
+	if err != nil {
+		s.Logger.Error("author::RestoreAuthor - failed to restore Author: ", err)
 		return err
 	}
 
 	return nil
 }
+
+func (s *AuthorService) PurgeDeletedAuthors(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := s.AuthorRepo.PurgeDeletedAuthors(ctx, retention)
+	if err != nil {
+		s.Logger.Error("author::PurgeDeletedAuthors - failed to purge deleted Author: ", err)
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+func (s *AuthorService) GetAuthorHistory(ctx context.Context, req *dto.GetAuthorHistoryRequest) (*dto.GetAuthorHistoryResponse, error) {
+	offset := (req.Page - 1) * req.Limit
+
+	versionData, total, err := s.AuthorRepo.FindAuthorVersions(ctx, req.ID, req.Limit, offset)
+	if err != nil {
+		s.Logger.Error("author::GetAuthorHistory - failed to find Author versions: ", err)
+		return nil, err
+	}
+
+	if total == 0 {
+		s.Logger.Error("author::GetAuthorHistory - Author has no history")
+		return nil, domain.NotFound(constants.ErrAuthorNotFound)
+	}
+
+	versions := make([]dto.AuthorVersion, 0, len(versionData))
+	for _, version := range versionData {
+		versions = append(versions, toAuthorVersionDTO(version))
+	}
+
+	return &dto.GetAuthorHistoryResponse{
+		VersionList: versions,
+		Pagination: dto.Pagination{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalItems: total,
+			TotalPages: (total + req.Limit - 1) / req.Limit,
+		},
+	}, nil
+}
+
+func (s *AuthorService) GetAuthorVersion(ctx context.Context, id string, version int) (*dto.AuthorVersion, error) {
+	versionData, err := s.AuthorRepo.FindAuthorVersion(ctx, id, version)
+	if err != nil {
+		s.Logger.Error("author::GetAuthorVersion - failed to find Author version: ", err)
+		return nil, err
+	}
+
+	res := toAuthorVersionDTO(*versionData)
+
+	return &res, nil
+}
+
+// RevertAuthor writes the fields captured in version back onto the author and
+// records the result as a new "revert" version, so history is never rewritten.
+// Like an update it overwrites the whole author, so it requires the version
+// the client last saw.
+func (s *AuthorService) RevertAuthor(ctx context.Context, id string, version, expectedVersion int) (*dto.GetDetailAuthorResponse, error) {
+	if expectedVersion <= 0 {
+		s.Logger.Error("author::RevertAuthor - missing expected version")
+		return nil, domain.PreconditionRequired(constants.ErrIfMatchRequired)
+	}
+
+	authorData, err := s.AuthorRepo.FindAuthorByID(ctx, id)
+	if err != nil {
+		s.Logger.Error("author::RevertAuthor - failed to find Author by id: ", err)
+		return nil, err
+	}
+
+	versionData, err := s.AuthorRepo.FindAuthorVersion(ctx, id, version)
+	if err != nil {
+		s.Logger.Error("author::RevertAuthor - failed to find Author version: ", err)
+		return nil, err
+	}
+
+	authorData.Name = versionData.Name
+	authorData.Bio = versionData.Bio
+	authorData.BirthDate = versionData.BirthDate
+	authorData.DeathDate = versionData.DeathDate
+
+	actor, _ := helpers.TokenDataFromContext(ctx)
+	authorData.Version = expectedVersion
+	err = s.AuthorRepo.UpdateNewAuthor(ctx, authorData, version, actor)
+	if err != nil {
+		s.Logger.Error("author::RevertAuthor - failed to update Author: ", err)
+		return nil, err
+	}
+
+	return toAuthorDetailResponse(*authorData), nil
+}
+
+func toAuthorDetailResponse(author models.Author) *dto.GetDetailAuthorResponse {
+	res := toAuthorDTO(author)
+
+	return &dto.GetDetailAuthorResponse{
+		ID:        res.ID,
+		Name:      res.Name,
+		Bio:       res.Bio,
+		BirthDate: res.BirthDate,
+		DeathDate: res.DeathDate,
+		Version:   res.Version,
+		CreatedAt: res.CreatedAt,
+		UpdatedAt: res.UpdatedAt,
+	}
+}
+
+func toAuthorVersionDTO(version models.AuthorVersion) dto.AuthorVersion {
+	return dto.AuthorVersion{
+		AuthorID:          version.AuthorID.String(),
+		Version:           version.Version,
+		Operation:         version.Operation,
+		Name:              version.Name,
+		Bio:               version.Bio,
+		BirthDate:         version.BirthDate.Format(constants.DateTimeFormat),
+		DeathDate:         helpers.FormatNullableDate(version.DeathDate, constants.DateTimeFormat),
+		DeletedAt:         helpers.FormatNullableDate(version.DeletedAt, constants.TimestampFormat),
+		RevertedFrom:      int(version.RevertedFrom.Int64),
+		ChangedBy:         version.ChangedBy,
+		ChangedByUsername: version.ChangedByUsername,
+		ChangedAt:         version.ChangedAt.Format(constants.TimestampFormat),
+	}
+}
@@ -0,0 +1,77 @@
+package author
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// readOnlyAuthorRepository serves author and panics on any write.
+type readOnlyAuthorRepository struct {
+	panicAuthorRepository
+	author models.Author
+}
+
+func (r readOnlyAuthorRepository) FindAuthorByID(ctx context.Context, id string) (*models.Author, error) {
+	author := r.author
+	return &author, nil
+}
+
+func TestPatchAuthorEmptyPatchWritesNothing(t *testing.T) {
+	author := models.Author{
+		ID:        uuid.New(),
+		Name:      "Ursula K. Le Guin",
+		Bio:       "Novelist",
+		BirthDate: time.Date(1929, 10, 21, 0, 0, 0, 0, time.UTC),
+		Version:   3,
+	}
+
+	tests := []struct {
+		name            string
+		expectedVersion int
+		wantStatus      int
+	}{
+		{name: "current version", expectedVersion: 3},
+		{name: "stale version", expectedVersion: 2, wantStatus: http.StatusPreconditionFailed},
+		{name: "no version", wantStatus: http.StatusPreconditionRequired},
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	s := &AuthorService{
+		AuthorRepo: readOnlyAuthorRepository{author: author},
+		Logger:     logger,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.PatchAuthor(context.Background(), &dto.PatchAuthorRequest{
+				ID:              author.ID.String(),
+				ExpectedVersion: tt.expectedVersion,
+			})
+
+			if tt.wantStatus != 0 {
+				if status := domain.HTTPStatus(err); status != tt.wantStatus {
+					t.Fatalf("HTTPStatus(err) = %d, want %d (err: %v)", status, tt.wantStatus, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("PatchAuthor() error = %v", err)
+			}
+			if res.Version != author.Version || res.Name != author.Name {
+				t.Errorf("PatchAuthor() = %+v, want the stored author at version %d", res, author.Version)
+			}
+		})
+	}
+}
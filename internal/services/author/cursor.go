@@ -0,0 +1,52 @@
+package author
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+)
+
+const cursorTimestampFormat = "2006-01-02 15:04:05.999999"
+
+type cursorPayload struct {
+	UpdatedAt string `json:"u"`
+	ID        string `json:"i"`
+	Backward  bool   `json:"b,omitempty"`
+	// Ascending is omitted for descending cursors, which were the only
+	// kind issued before the direction was recorded.
+	Ascending bool `json:"a,omitempty"`
+}
+
+// encodeCursor builds the opaque keyset cursor pointing at author for a
+// list sorted by updated_at in the given direction. A backward cursor pages
+// towards the start of the list.
+func encodeCursor(author models.Author, desc, backward bool) string {
+	payload, _ := json.Marshal(cursorPayload{
+		UpdatedAt: author.UpdatedAt.Format(cursorTimestampFormat),
+		ID:        author.ID.String(),
+		Backward:  backward,
+		Ascending: !desc,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeCursor(cursor string) (*models.AuthorCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, err
+	}
+
+	return &models.AuthorCursor{
+		UpdatedAt: payload.UpdatedAt,
+		ID:        payload.ID,
+		Backward:  payload.Backward,
+		Desc:      !payload.Ascending,
+	}, nil
+}
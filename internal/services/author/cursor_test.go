@@ -0,0 +1,116 @@
+package author
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	author := models.Author{
+		ID:        uuid.New(),
+		UpdatedAt: time.Date(2026, 10, 18, 9, 30, 15, 123456000, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		desc     bool
+		backward bool
+	}{
+		{name: "descending forward", desc: true},
+		{name: "descending backward", desc: true, backward: true},
+		{name: "ascending forward"},
+		{name: "ascending backward", backward: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := decodeCursor(encodeCursor(author, tt.desc, tt.backward))
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+
+			want := models.AuthorCursor{
+				UpdatedAt: "2026-10-18 09:30:15.123456",
+				ID:        author.ID.String(),
+				Backward:  tt.backward,
+				Desc:      tt.desc,
+			}
+			if *cursor != want {
+				t.Errorf("decodeCursor() = %+v, want %+v", *cursor, want)
+			}
+		})
+	}
+}
+
+// panicAuthorRepository fails any test that reaches the database.
+type panicAuthorRepository struct {
+	interfaces.IAuthorRepository
+}
+
+func TestGetListAuthorRejectsMalformedCursors(t *testing.T) {
+	encode := func(payload string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(payload))
+	}
+
+	id := uuid.NewString()
+
+	tests := []struct {
+		name   string
+		cursor string
+		sort   string
+		order  string
+	}{
+		{name: "not base64", cursor: "not a cursor!"},
+		{name: "padded base64", cursor: base64.URLEncoding.EncodeToString([]byte(`{"u":"2026-10-18 09:30:15","i":"` + id + `"}`))},
+		{name: "not json", cursor: encode("updated_at > now()")},
+		{name: "json null", cursor: encode("null")},
+		{name: "json array", cursor: encode(`["2026-10-18 09:30:15","` + id + `"]`)},
+		{name: "wrong field types", cursor: encode(`{"u":1,"i":2}`)},
+		{name: "missing id", cursor: encode(`{"u":"2026-10-18 09:30:15"}`)},
+		{name: "sql in id", cursor: encode(`{"u":"2026-10-18 09:30:15","i":"' OR 1=1 --"}`)},
+		{name: "missing timestamp", cursor: encode(`{"i":"` + id + `"}`)},
+		{name: "sql in timestamp", cursor: encode(`{"u":"2026-10-18'; DROP TABLE authors; --","i":"` + id + `"}`)},
+		{name: "oversized timestamp", cursor: encode(`{"u":"` + strings.Repeat("9", 300) + `","i":"` + id + `"}`)},
+		{name: "ascending cursor on descending list", cursor: encode(`{"u":"2026-10-18 09:30:15","i":"` + id + `","a":true}`)},
+		{name: "descending cursor on ascending list", cursor: encode(`{"u":"2026-10-18 09:30:15","i":"` + id + `"}`), sort: "updated_at", order: "asc"},
+		{name: "unsupported sort", cursor: encode(`{"u":"2026-10-18 09:30:15","i":"` + id + `"}`), sort: "name"},
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	s := &AuthorService{
+		AuthorRepo: panicAuthorRepository{},
+		Logger:     logger,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.GetListAuthor(context.Background(), &dto.GetListAuthorRequest{
+				Page:   1,
+				Limit:  10,
+				Sort:   tt.sort,
+				Order:  tt.order,
+				Cursor: tt.cursor,
+			})
+			if err == nil {
+				t.Fatal("GetListAuthor() error = nil, want a validation error")
+			}
+			if status := domain.HTTPStatus(err); status != http.StatusBadRequest {
+				t.Errorf("HTTPStatus(err) = %d, want %d (err: %v)", status, http.StatusBadRequest, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package author
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+)
+
+// importBatchSize is the number of authors written per transaction.
+const importBatchSize = 500
+
+type importCandidate struct {
+	result int
+	author *models.Author
+}
+
+// ImportAuthors creates the valid rows of req that do not match an existing
+// author by name and birth date, importBatchSize rows per transaction. Rows are
+// reported in input order. In dry-run mode nothing is written and rows that
+// would be created are reported as valid.
+func (s *AuthorService) ImportAuthors(ctx context.Context, req *dto.ImportAuthorsRequest) (*dto.ImportAuthorsResponse, error) {
+	var (
+		response = &dto.ImportAuthorsResponse{
+			DryRun: req.DryRun,
+			Total:  len(req.Rows),
+			Rows:   make([]dto.ImportAuthorResult, len(req.Rows)),
+		}
+		candidates = make([]importCandidate, 0, len(req.Rows))
+		identities = make([]models.AuthorIdentity, 0, len(req.Rows))
+	)
+
+	for i, row := range req.Rows {
+		result := &response.Rows[i]
+		result.Line = row.Line
+		result.Name = row.Name
+
+		if len(row.Errors) > 0 {
+			result.Status = constants.ImportStatusInvalid
+			result.Errors = row.Errors
+			continue
+		}
+
+		author, errs := parseImportRow(row)
+		if len(errs) > 0 {
+			result.Status = constants.ImportStatusInvalid
+			result.Errors = errs
+			continue
+		}
+
+		candidates = append(candidates, importCandidate{result: i, author: author})
+		identities = append(identities, models.AuthorIdentity{Name: author.Name, BirthDate: author.BirthDate})
+	}
+
+	existing := req.Seen
+	if existing == nil {
+		existing = make(map[string]struct{})
+	}
+	for start := 0; start < len(identities); start += importBatchSize {
+		end := min(start+importBatchSize, len(identities))
+
+		found, err := s.AuthorRepo.FindExistingAuthorIdentities(ctx, identities[start:end])
+		if err != nil {
+			s.Logger.Error("author::ImportAuthors - failed to find existing Author: ", err)
+			return nil, err
+		}
+
+		for _, identity := range found {
+			existing[identityKey(identity)] = struct{}{}
+		}
+	}
+
+	pending := make([]importCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		key := identityKey(models.AuthorIdentity{Name: candidate.author.Name, BirthDate: candidate.author.BirthDate})
+		if _, ok := existing[key]; ok {
+			response.Rows[candidate.result].Status = constants.ImportStatusDuplicate
+			response.Rows[candidate.result].Reason = constants.ErrImportDuplicate
+			continue
+		}
+		// Later rows with the same identity are duplicates of this one.
+		existing[key] = struct{}{}
+
+		if req.DryRun {
+			response.Rows[candidate.result].Status = constants.ImportStatusValid
+			continue
+		}
+
+		pending = append(pending, candidate)
+	}
+
+	actor, _ := helpers.TokenDataFromContext(ctx)
+	for start := 0; start < len(pending); start += importBatchSize {
+		batch := pending[start:min(start+importBatchSize, len(pending))]
+
+		authors := make([]*models.Author, 0, len(batch))
+		for _, candidate := range batch {
+			authors = append(authors, candidate.author)
+		}
+
+		if err := s.AuthorRepo.InsertNewAuthors(ctx, authors, actor); err != nil {
+			s.Logger.Error("author::ImportAuthors - failed to insert Author batch: ", err)
+			for _, candidate := range batch {
+				response.Rows[candidate.result].Status = constants.ImportStatusFailed
+				response.Rows[candidate.result].Reason = constants.ErrImportFailed
+			}
+			continue
+		}
+
+		for _, candidate := range batch {
+			response.Rows[candidate.result].Status = constants.ImportStatusCreated
+			response.Rows[candidate.result].ID = candidate.author.ID.String()
+		}
+	}
+
+	for _, row := range response.Rows {
+		switch row.Status {
+		case constants.ImportStatusCreated:
+			response.Created++
+		case constants.ImportStatusDuplicate:
+			response.Duplicates++
+		case constants.ImportStatusInvalid:
+			response.Invalid++
+		case constants.ImportStatusFailed:
+			response.Failed++
+		}
+	}
+
+	return response, nil
+}
+
+func parseImportRow(row dto.ImportAuthorRow) (*models.Author, map[string][]string) {
+	errs := make(map[string][]string)
+
+	birthDate, err := helpers.ParseDate(row.BirthDate, constants.DateTimeFormat)
+	if err != nil {
+		errs["birth_date"] = append(errs["birth_date"], constants.ErrInvalidFormatDate)
+	}
+
+	author := &models.Author{
+		Name:      strings.TrimSpace(row.Name),
+		Bio:       strings.TrimSpace(row.Bio),
+		BirthDate: birthDate,
+	}
+
+	if row.DeathDate != "" {
+		deathDate, err := helpers.ParseDate(row.DeathDate, constants.DateTimeFormat)
+		if err != nil {
+			errs["death_date"] = append(errs["death_date"], constants.ErrInvalidFormatDate)
+		}
+		author.DeathDate = helpers.NullTimeScan(deathDate)
+	}
+
+	return author, errs
+}
+
+func identityKey(identity models.AuthorIdentity) string {
+	return strings.ToLower(identity.Name) + "|" + identity.BirthDate.Format(constants.DateTimeFormat)
+}
@@ -0,0 +1,81 @@
+package author
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+)
+
+// defaultDuplicateMinScore keeps pairs whose names are close and whose dates
+// do not contradict each other.
+const defaultDuplicateMinScore = 0.7
+
+func (s *AuthorService) GetDuplicateCandidates(ctx context.Context, req *dto.GetDuplicateCandidatesRequest) (*dto.GetDuplicateCandidatesResponse, error) {
+	if req.MinScore == 0 {
+		req.MinScore = defaultDuplicateMinScore
+	}
+
+	duplicates, total, err := s.AuthorRepo.FindDuplicateCandidates(ctx, models.AuthorDuplicateFilter{
+		AuthorID: req.AuthorID,
+		MinScore: req.MinScore,
+		Limit:    req.Limit,
+		Offset:   (req.Page - 1) * req.Limit,
+	})
+	if err != nil {
+		s.Logger.Error("author::GetDuplicateCandidates - failed to find duplicate candidates: ", err)
+		return nil, err
+	}
+
+	candidates := make([]dto.DuplicateCandidate, 0, len(duplicates))
+	for _, duplicate := range duplicates {
+		candidates = append(candidates, dto.DuplicateCandidate{
+			Author:         toAuthorDTO(duplicate.Author),
+			Duplicate:      toAuthorDTO(duplicate.Duplicate),
+			Score:          duplicate.Score,
+			NameSimilarity: duplicate.NameScore,
+		})
+	}
+
+	return &dto.GetDuplicateCandidatesResponse{
+		CandidateList: candidates,
+		Pagination: dto.Pagination{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalItems: total,
+			TotalPages: (total + req.Limit - 1) / req.Limit,
+		},
+	}, nil
+}
+
+// MergeAuthors merges req.SourceID into req.TargetID and returns the merged
+// target. Requests for the source ID are served the target afterwards.
+func (s *AuthorService) MergeAuthors(ctx context.Context, req *dto.MergeAuthorsRequest) (*dto.GetDetailAuthorResponse, error) {
+	if strings.EqualFold(req.SourceID, req.TargetID) {
+		s.Logger.Error("author::MergeAuthors - source and target are the same author")
+		return nil, domain.Validation(constants.ErrMergeSameAuthor).WithField("source_id", constants.ErrMergeSameAuthor)
+	}
+
+	actor, _ := helpers.TokenDataFromContext(ctx)
+
+	target, err := s.AuthorRepo.MergeAuthors(ctx, req.SourceID, req.TargetID, actor)
+	if err != nil {
+		s.Logger.Error("author::MergeAuthors - failed to merge Author: ", err)
+		return nil, err
+	}
+
+	return &dto.GetDetailAuthorResponse{
+		ID:        target.ID.String(),
+		Name:      target.Name,
+		Bio:       target.Bio,
+		BirthDate: target.BirthDate.Format(constants.DateTimeFormat),
+		DeathDate: helpers.FormatNullableDate(target.DeathDate, constants.DateTimeFormat),
+		Version:   target.Version,
+		CreatedAt: target.CreatedAt.Format(constants.TimestampFormat),
+		UpdatedAt: target.UpdatedAt.Format(constants.TimestampFormat),
+	}, nil
+}
@@ -0,0 +1,151 @@
+package author
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+)
+
+// eventTimestampFormat is how jsonb renders TIMESTAMP columns in the event
+// payload.
+const eventTimestampFormat = "2006-01-02T15:04:05.999999"
+
+const watchBatchSize = 100
+
+type authorEventPayload struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Bio       *string `json:"bio"`
+	BirthDate *string `json:"birth_date"`
+	DeathDate *string `json:"death_date"`
+	Version   int     `json:"version"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+	DeletedAt *string `json:"deleted_at"`
+
+	// MergedInto is only set on merge events.
+	MergedInto *string `json:"merged_into"`
+}
+
+// WatchAuthorEvents replays the events after req.ResumeToken and then follows
+// new ones, calling send for each until ctx is done or send fails. Without a
+// token it starts from the latest event.
+func (s *AuthorService) WatchAuthorEvents(ctx context.Context, req *dto.WatchAuthorsRequest, send func(dto.AuthorEvent) error) error {
+	// Subscribe before reading so no wake-up between the two is lost.
+	wake, unsubscribe := s.Notifier.Subscribe()
+	defer unsubscribe()
+
+	cursor, err := s.resolveResumeToken(ctx, req.ResumeToken)
+	if err != nil {
+		return err
+	}
+
+	authorIDs := make(map[string]struct{}, len(req.AuthorIDs))
+	for _, id := range req.AuthorIDs {
+		authorIDs[strings.ToLower(id)] = struct{}{}
+	}
+
+	for {
+		for {
+			events, err := s.AuthorRepo.FindAuthorEventsAfter(ctx, cursor, watchBatchSize)
+			if err != nil {
+				s.Logger.Error("author::WatchAuthorEvents - failed to find Author events: ", err)
+				return err
+			}
+
+			for _, event := range events {
+				cursor = event.Sequence
+
+				if _, ok := authorIDs[event.AuthorID.String()]; len(authorIDs) > 0 && !ok {
+					continue
+				}
+
+				res, err := toAuthorEventDTO(event)
+				if err != nil {
+					s.Logger.Error("author::WatchAuthorEvents - failed to decode Author event: ", err)
+					return err
+				}
+
+				if err := send(res); err != nil {
+					return err
+				}
+			}
+
+			if len(events) < watchBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+		}
+	}
+}
+
+func (s *AuthorService) resolveResumeToken(ctx context.Context, token string) (int64, error) {
+	if token == "" {
+		latest, err := s.AuthorRepo.FindLatestAuthorEventSequence(ctx)
+		if err != nil {
+			s.Logger.Error("author::WatchAuthorEvents - failed to find latest Author event sequence: ", err)
+			return 0, err
+		}
+		return latest, nil
+	}
+
+	cursor, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || cursor < 0 {
+		s.Logger.Error("author::WatchAuthorEvents - failed to parse resume token: ", err)
+		return 0, domain.Validation(constants.ErrInvalidResumeToken).WithField("resume_token", constants.ErrInvalidResumeToken)
+	}
+
+	return cursor, nil
+}
+
+func toAuthorEventDTO(event models.AuthorEvent) (dto.AuthorEvent, error) {
+	var payload authorEventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return dto.AuthorEvent{}, err
+	}
+
+	return dto.AuthorEvent{
+		ID:        strconv.FormatInt(event.Sequence, 10),
+		EventType: event.EventType,
+		Author: dto.Author{
+			ID:        payload.ID,
+			Name:      payload.Name,
+			Bio:       stringValue(payload.Bio),
+			BirthDate: stringValue(payload.BirthDate),
+			DeathDate: stringValue(payload.DeathDate),
+			Version:   payload.Version,
+			CreatedAt: formatEventTimestamp(payload.CreatedAt),
+			UpdatedAt: formatEventTimestamp(payload.UpdatedAt),
+			DeletedAt: formatEventTimestamp(stringValue(payload.DeletedAt)),
+		},
+		MergedInto: stringValue(payload.MergedInto),
+		OccurredAt: event.CreatedAt.Format(constants.TimestampFormat),
+	}, nil
+}
+
+func formatEventTimestamp(value string) string {
+	t, err := time.Parse(eventTimestampFormat, value)
+	if err != nil {
+		return value
+	}
+	return t.Format(constants.TimestampFormat)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
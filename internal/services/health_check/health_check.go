@@ -1,11 +1,32 @@
 package healthcheck
 
-import "github.com/hilmiikhsan/library-author-service/internal/interfaces"
+import (
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+)
 
 type Healthcheck struct {
 	HealthcheckRepository interfaces.IHealthcheckRepo
+	AuthBreaker           interfaces.ICircuitBreaker
 }
 
-func (s *Healthcheck) HealthcheckServices() (string, error) {
-	return "service healthy", nil
+// HealthcheckServices reports the service as degraded while the auth
+// service breaker is not closed. It stays up: cached tokens and local JWT
+// verification keep working.
+func (s *Healthcheck) HealthcheckServices() (dto.Healthcheck, string, error) {
+	res := dto.Healthcheck{
+		Status: constants.HealthStatusHealthy,
+		AuthService: dto.AuthServiceHealth{
+			Breaker:  s.AuthBreaker.State(),
+			Failures: s.AuthBreaker.Failures(),
+		},
+	}
+
+	if res.AuthService.Breaker != constants.BreakerClosed {
+		res.Status = constants.HealthStatusDegraded
+		return res, "service degraded: auth service unavailable", nil
+	}
+
+	return res, "service healthy", nil
 }
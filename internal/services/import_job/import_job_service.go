@@ -0,0 +1,314 @@
+package importjob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+type ImportJobService struct {
+	ImportJobRepo interfaces.IImportJobRepository
+	AuthorService interfaces.IAuthorService
+	Locker        interfaces.ILocker
+	Logger        *logrus.Logger
+
+	// ChunkSize rows are imported between progress updates and
+	// cancellation checks.
+	ChunkSize int
+	// LockTTL bounds how long a job stays locked after its worker dies.
+	LockTTL time.Duration
+}
+
+func (s *ImportJobService) CreateImportJob(ctx context.Context, req *dto.CreateImportJobRequest) (*dto.ImportJob, error) {
+	rows := make([]models.ImportJobRow, 0, len(req.Rows))
+	for _, row := range req.Rows {
+		rows = append(rows, models.ImportJobRow{
+			Line:      row.Line,
+			Name:      row.Name,
+			Bio:       row.Bio,
+			BirthDate: row.BirthDate,
+			DeathDate: row.DeathDate,
+			Errors:    row.Errors,
+		})
+	}
+
+	rowsJSON, err := json.Marshal(rows)
+	if err != nil {
+		s.Logger.Error("importjob::CreateImportJob - failed to marshal import rows: ", err)
+		return nil, err
+	}
+
+	actor, _ := helpers.TokenDataFromContext(ctx)
+
+	job := &models.ImportJob{
+		FileName:          req.FileName,
+		Format:            req.Format,
+		DryRun:            req.DryRun,
+		Rows:              rowsJSON,
+		TotalRows:         len(rows),
+		CreatedByID:       actor.UserID,
+		CreatedByUsername: actor.Username,
+	}
+
+	err = s.ImportJobRepo.InsertImportJob(ctx, job)
+	if err != nil {
+		s.Logger.Error("importjob::CreateImportJob - failed to insert import job: ", err)
+		return nil, err
+	}
+
+	res := toImportJobDTO(*job)
+
+	return &res, nil
+}
+
+func (s *ImportJobService) GetImportJob(ctx context.Context, id string) (*dto.ImportJob, error) {
+	job, err := s.ImportJobRepo.FindImportJobByID(ctx, id)
+	if err != nil {
+		s.Logger.Error("importjob::GetImportJob - failed to find import job: ", err)
+		return nil, err
+	}
+
+	res := toImportJobDTO(*job)
+
+	return &res, nil
+}
+
+// CancelImportJob stops a queued or running job. Rows imported before the
+// worker notices the cancellation are kept.
+func (s *ImportJobService) CancelImportJob(ctx context.Context, id string) (*dto.ImportJob, error) {
+	err := s.ImportJobRepo.CancelImportJob(ctx, id)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		s.Logger.Error("importjob::CancelImportJob - failed to cancel import job: ", err)
+		return nil, err
+	}
+	cancelled := err == nil
+
+	job, err := s.ImportJobRepo.FindImportJobByID(ctx, id)
+	if err != nil {
+		s.Logger.Error("importjob::CancelImportJob - failed to find import job: ", err)
+		return nil, err
+	}
+
+	if !cancelled {
+		s.Logger.Error("importjob::CancelImportJob - import job has already finished")
+		return nil, domain.Conflict(constants.ErrImportJobFinished)
+	}
+
+	res := toImportJobDTO(*job)
+
+	return &res, nil
+}
+
+// GetImportJobErrors returns every row of the job that was not imported.
+func (s *ImportJobService) GetImportJobErrors(ctx context.Context, id string) ([]dto.ImportAuthorResult, error) {
+	rowErrors, err := s.ImportJobRepo.FindImportJobRowErrors(ctx, id)
+	if err != nil {
+		s.Logger.Error("importjob::GetImportJobErrors - failed to find import job row errors: ", err)
+		return nil, err
+	}
+
+	res := make([]dto.ImportAuthorResult, 0, len(rowErrors))
+	for _, rowError := range rowErrors {
+		res = append(res, dto.ImportAuthorResult{
+			Line:   rowError.Line,
+			Status: rowError.Status,
+			Name:   rowError.Name,
+			Reason: rowError.Reason,
+			Errors: rowError.Errors,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *ImportJobService) FindPendingImportJobIDs(ctx context.Context, limit int) ([]string, error) {
+	return s.ImportJobRepo.FindPendingImportJobIDs(ctx, limit)
+}
+
+// ProcessImportJob runs the job unless another worker holds its lock. The
+// import resumes after the last recorded chunk, so a job whose worker died
+// is picked up again once its lock expires.
+func (s *ImportJobService) ProcessImportJob(ctx context.Context, id string) error {
+	lockName := "import_job:" + id
+
+	token, ok, err := s.Locker.Acquire(ctx, lockName, s.LockTTL)
+	if err != nil {
+		s.Logger.Error("importjob::ProcessImportJob - failed to acquire import job lock: ", err)
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	defer s.Locker.Release(context.Background(), lockName, token)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go s.keepLock(ctx, cancel, lockName, token)
+
+	job, err := s.ImportJobRepo.StartImportJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		s.Logger.Error("importjob::ProcessImportJob - failed to start import job: ", err)
+		return err
+	}
+
+	// Workers have no caller of their own; history and events name whoever
+	// created the job.
+	ctx = helpers.ContextWithTokenData(ctx, models.TokenData{
+		UserID:   job.CreatedByID,
+		Username: job.CreatedByUsername,
+	})
+
+	rows := make([]models.ImportJobRow, 0, job.TotalRows)
+	if err := job.Rows.Unmarshal(&rows); err != nil {
+		s.Logger.Error("importjob::ProcessImportJob - failed to unmarshal import rows: ", err)
+		return s.finish(id, constants.ImportJobStatusFailed, constants.ErrImportFormat)
+	}
+
+	seen := make(map[string]struct{})
+	for start := job.ProcessedRows; start < len(rows); start += s.ChunkSize {
+		chunk := rows[start:min(start+s.ChunkSize, len(rows))]
+
+		progress, err := s.importChunk(ctx, job.DryRun, chunk, seen)
+		if err != nil {
+			if ctx.Err() != nil {
+				// The lock was lost or the service is stopping. Whoever
+				// takes the lock next resumes the job.
+				return nil
+			}
+			s.Logger.Error("importjob::ProcessImportJob - failed to import chunk: ", err)
+			return s.finish(id, constants.ImportJobStatusFailed, domain.Public(err).Msg)
+		}
+
+		err = s.ImportJobRepo.UpdateImportJobProgress(ctx, id, progress)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				s.Logger.Infof("import job %s was cancelled", id)
+				return nil
+			}
+			s.Logger.Error("importjob::ProcessImportJob - failed to update import job progress: ", err)
+			return err
+		}
+	}
+
+	return s.finish(id, constants.ImportJobStatusCompleted, "")
+}
+
+func (s *ImportJobService) importChunk(ctx context.Context, dryRun bool, chunk []models.ImportJobRow, seen map[string]struct{}) (models.ImportJobProgress, error) {
+	req := &dto.ImportAuthorsRequest{
+		Rows:   make([]dto.ImportAuthorRow, 0, len(chunk)),
+		DryRun: dryRun,
+		Seen:   seen,
+	}
+	for _, row := range chunk {
+		req.Rows = append(req.Rows, dto.ImportAuthorRow{
+			Line:      row.Line,
+			Name:      row.Name,
+			Bio:       row.Bio,
+			BirthDate: row.BirthDate,
+			DeathDate: row.DeathDate,
+			Errors:    row.Errors,
+		})
+	}
+
+	res, err := s.AuthorService.ImportAuthors(ctx, req)
+	if err != nil {
+		return models.ImportJobProgress{}, err
+	}
+
+	progress := models.ImportJobProgress{
+		Processed:  len(chunk),
+		Created:    res.Created,
+		Duplicates: res.Duplicates,
+		Invalid:    res.Invalid,
+		Failed:     res.Failed,
+	}
+
+	for _, row := range res.Rows {
+		switch row.Status {
+		case constants.ImportStatusCreated:
+			continue
+		case constants.ImportStatusValid:
+			progress.Valid++
+			continue
+		}
+
+		progress.RowErrors = append(progress.RowErrors, models.ImportJobRowError{
+			Line:   row.Line,
+			Name:   row.Name,
+			Status: row.Status,
+			Reason: row.Reason,
+			Errors: row.Errors,
+		})
+	}
+
+	return progress, nil
+}
+
+// keepLock refreshes the job lock until ctx ends and cancels the job as soon
+// as the lock is lost.
+func (s *ImportJobService) keepLock(ctx context.Context, cancel context.CancelFunc, lockName, token string) {
+	ticker := time.NewTicker(s.LockTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			held, err := s.Locker.Refresh(ctx, lockName, token, s.LockTTL)
+			if err != nil {
+				continue
+			}
+			if !held {
+				s.Logger.Warn("importjob::keepLock - lost import job lock: ", lockName)
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+func (s *ImportJobService) finish(id, status, errorMessage string) error {
+	err := s.ImportJobRepo.FinishImportJob(context.Background(), id, status, errorMessage)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		s.Logger.Error("importjob::finish - failed to finish import job: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func toImportJobDTO(job models.ImportJob) dto.ImportJob {
+	return dto.ImportJob{
+		ID:            job.ID.String(),
+		Status:        job.Status,
+		FileName:      job.FileName,
+		Format:        job.Format,
+		DryRun:        job.DryRun,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		CreatedRows:   job.CreatedRows,
+		ValidRows:     job.ValidRows,
+		DuplicateRows: job.DuplicateRows,
+		InvalidRows:   job.InvalidRows,
+		FailedRows:    job.FailedRows,
+		ErrorMessage:  job.ErrorMessage,
+		CreatedBy:     job.CreatedByUsername,
+		CreatedAt:     job.CreatedAt.Format(constants.TimestampFormat),
+		UpdatedAt:     job.UpdatedAt.Format(constants.TimestampFormat),
+		StartedAt:     helpers.FormatNullableDate(job.StartedAt, constants.TimestampFormat),
+		FinishedAt:    helpers.FormatNullableDate(job.FinishedAt, constants.TimestampFormat),
+	}
+}
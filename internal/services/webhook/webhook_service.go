@@ -0,0 +1,277 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hilmiikhsan/library-author-service/constants"
+	"github.com/hilmiikhsan/library-author-service/helpers"
+	"github.com/hilmiikhsan/library-author-service/internal/dto"
+	"github.com/hilmiikhsan/library-author-service/internal/interfaces"
+	"github.com/hilmiikhsan/library-author-service/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	headerWebhookID        = "X-Webhook-Id"
+	headerWebhookEvent     = "X-Webhook-Event"
+	headerWebhookTimestamp = "X-Webhook-Timestamp"
+	headerWebhookSignature = "X-Webhook-Signature"
+
+	// maxErrorLength bounds the response excerpt kept in the delivery log.
+	maxErrorLength = 512
+)
+
+type WebhookService struct {
+	WebhookRepo interfaces.IWebhookRepository
+	Logger      *logrus.Logger
+	HTTPClient  *http.Client
+
+	MaxAttempts int
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, req *dto.CreateWebhookRequest) (*dto.Webhook, error) {
+	actor, _ := helpers.TokenDataFromContext(ctx)
+
+	subscription := &models.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		CreatedBy:  actor.Username,
+	}
+
+	err := s.WebhookRepo.InsertSubscription(ctx, subscription)
+	if err != nil {
+		s.Logger.Error("webhook::CreateWebhook - failed to insert webhook subscription: ", err)
+		return nil, err
+	}
+
+	res := toWebhookDTO(*subscription)
+
+	return &res, nil
+}
+
+func (s *WebhookService) GetListWebhook(ctx context.Context, req *dto.GetListWebhookRequest) (*dto.GetListWebhookResponse, error) {
+	subscriptions, total, err := s.WebhookRepo.FindSubscriptions(ctx, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		s.Logger.Error("webhook::GetListWebhook - failed to find webhook subscriptions: ", err)
+		return nil, err
+	}
+
+	webhooks := make([]dto.Webhook, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		webhooks = append(webhooks, toWebhookDTO(subscription))
+	}
+
+	return &dto.GetListWebhookResponse{
+		WebhookList: webhooks,
+		Pagination: dto.Pagination{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalItems: total,
+			TotalPages: (total + req.Limit - 1) / req.Limit,
+		},
+	}, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	err := s.WebhookRepo.DeleteSubscription(ctx, id)
+	if err != nil {
+		s.Logger.Error("webhook::DeleteWebhook - failed to delete webhook subscription: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *WebhookService) GetWebhookDeliveries(ctx context.Context, req *dto.GetWebhookDeliveriesRequest) (*dto.GetWebhookDeliveriesResponse, error) {
+	deliveries, total, err := s.WebhookRepo.FindDeliveries(ctx, models.WebhookDeliveryFilter{
+		SubscriptionID: req.SubscriptionID,
+		Status:         req.Status,
+		Limit:          req.Limit,
+		Offset:         (req.Page - 1) * req.Limit,
+	})
+	if err != nil {
+		s.Logger.Error("webhook::GetWebhookDeliveries - failed to find webhook deliveries: ", err)
+		return nil, err
+	}
+
+	deliveryList := make([]dto.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryList = append(deliveryList, toWebhookDeliveryDTO(delivery))
+	}
+
+	return &dto.GetWebhookDeliveriesResponse{
+		DeliveryList: deliveryList,
+		Pagination: dto.Pagination{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalItems: total,
+			TotalPages: (total + req.Limit - 1) / req.Limit,
+		},
+	}, nil
+}
+
+// RetryWebhookDelivery moves a dead-lettered delivery back to the queue with
+// a fresh attempt budget.
+func (s *WebhookService) RetryWebhookDelivery(ctx context.Context, id int64) error {
+	err := s.WebhookRepo.RetryDelivery(ctx, id)
+	if err != nil {
+		s.Logger.Error("webhook::RetryWebhookDelivery - failed to retry webhook delivery: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// DispatchDueDeliveries sends up to limit due deliveries concurrently and
+// returns how many were claimed.
+func (s *WebhookService) DispatchDueDeliveries(ctx context.Context, limit int) (int, error) {
+	// The lease outlives one request so a claim is never sent twice at once.
+	lease := s.HTTPClient.Timeout + time.Minute
+
+	deliveries, err := s.WebhookRepo.ClaimDueDeliveries(ctx, limit, lease)
+	if err != nil {
+		s.Logger.Error("webhook::DispatchDueDeliveries - failed to claim webhook deliveries: ", err)
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery models.WebhookDelivery) {
+			defer wg.Done()
+			s.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+func (s *WebhookService) deliver(ctx context.Context, delivery models.WebhookDelivery) {
+	statusCode, err := s.send(ctx, delivery)
+	if err == nil {
+		if err := s.WebhookRepo.MarkDelivered(ctx, delivery.ID, statusCode); err != nil {
+			s.Logger.Error("webhook::deliver - failed to mark webhook delivered: ", err)
+		}
+		return
+	}
+
+	s.Logger.Warnf("webhook::deliver - delivery %d attempt %d failed: %v", delivery.ID, delivery.Attempts+1, err)
+
+	errMsg := err.Error()
+	if len(errMsg) > maxErrorLength {
+		errMsg = errMsg[:maxErrorLength]
+	}
+
+	if err := s.WebhookRepo.MarkFailed(ctx, delivery.ID, s.MaxAttempts, s.backoff(delivery.Attempts), statusCode, errMsg); err != nil {
+		s.Logger.Error("webhook::deliver - failed to mark webhook failed: ", err)
+	}
+}
+
+func (s *WebhookService) send(ctx context.Context, delivery models.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(map[string]any{
+		"id":          strconv.FormatInt(delivery.ID, 10),
+		"event":       delivery.EventType,
+		"occurred_at": delivery.CreatedAt.Format(constants.TimestampFormat),
+		"data":        delivery.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerWebhookID, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(headerWebhookEvent, delivery.EventType)
+	req.Header.Set(headerWebhookTimestamp, timestamp)
+	req.Header.Set(headerWebhookSignature, "sha256="+sign(delivery.Secret, timestamp, body))
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, nil
+	}
+
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorLength))
+	return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, excerpt)
+}
+
+// backoff doubles the wait after every failed attempt, capped at BackoffMax.
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	wait := s.BackoffBase
+	for i := 0; i < attempts && wait < s.BackoffMax; i++ {
+		wait *= 2
+	}
+
+	if wait > s.BackoffMax {
+		wait = s.BackoffMax
+	}
+
+	return wait
+}
+
+// sign returns the hex HMAC-SHA256 of "<timestamp>.<body>". Receivers
+// recompute it with their secret and reject stale timestamps.
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func toWebhookDTO(subscription models.WebhookSubscription) dto.Webhook {
+	return dto.Webhook{
+		ID:         subscription.ID.String(),
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		IsActive:   subscription.IsActive,
+		CreatedBy:  subscription.CreatedBy,
+		CreatedAt:  subscription.CreatedAt.Format(constants.TimestampFormat),
+		UpdatedAt:  subscription.UpdatedAt.Format(constants.TimestampFormat),
+	}
+}
+
+func toWebhookDeliveryDTO(delivery models.WebhookDelivery) dto.WebhookDelivery {
+	res := dto.WebhookDelivery{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID.String(),
+		EventType:      delivery.EventType,
+		AuthorID:       delivery.AuthorID.String(),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: int(delivery.LastStatusCode.Int64),
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(constants.TimestampFormat),
+		DeliveredAt:    helpers.FormatNullableDate(delivery.DeliveredAt, constants.TimestampFormat),
+	}
+
+	if delivery.Status == constants.WebhookStatusPending {
+		res.NextAttemptAt = helpers.FormatNullableDate(delivery.NextAttemptAt, constants.TimestampFormat)
+	}
+
+	return res
+}
@@ -7,6 +7,7 @@ import (
 	// "github.com/go-playground/locales/en"
 	// ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	"github.com/hilmiikhsan/library-author-service/internal/domain"
 	// en_translations "github.com/go-playground/validator/v10/translations/en"
 	log "github.com/sirupsen/logrus"
 )
@@ -74,7 +75,10 @@ func NewValidator() *Validator {
 }
 
 func (v *Validator) Validate(i any) error {
-	return v.validator.Struct(i)
+	if err := v.validator.Struct(i); err != nil {
+		return domain.FromValidation(err, i)
+	}
+	return nil
 }
 
 // blacklist email validator
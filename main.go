@@ -41,6 +41,34 @@ func main() {
 		cmd.ServeHTTP()
 	}()
 
+	// Run soft-deleted author purge job
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cmd.RunPurgeJob()
+	}()
+
+	// Run author event outbox relay
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cmd.RunOutboxRelay()
+	}()
+
+	// Run webhook delivery dispatcher
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cmd.RunWebhookDispatcher()
+	}()
+
+	// Run author import job workers
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cmd.RunImportWorkers()
+	}()
+
 	// Graceful shutdown
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE authors ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_authors_deleted_at ON authors (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_authors_active_updated_at ON authors (updated_at DESC, id DESC) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_authors_active_updated_at;
+DROP INDEX IF EXISTS idx_authors_deleted_at;
+ALTER TABLE authors DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS author_versions (
+    id BIGSERIAL PRIMARY KEY,
+    author_id UUID NOT NULL,
+    version INT NOT NULL,
+    operation VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    bio TEXT,
+    birth_date DATE,
+    death_date DATE,
+    deleted_at TIMESTAMP,
+    reverted_from INT,
+    changed_by VARCHAR(255),
+    changed_by_username VARCHAR(255),
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (author_id, version)
+);
+
+INSERT INTO author_versions (author_id, version, operation, name, bio, birth_date, death_date, deleted_at, changed_at)
+SELECT id, 1, 'create', name, bio, birth_date, death_date, deleted_at, updated_at
+FROM authors;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS author_versions;
+-- +goose StatementEnd
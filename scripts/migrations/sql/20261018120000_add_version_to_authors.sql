@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE authors ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- Authors already have history numbered by author_versions; continue from
+-- it so the next snapshot does not collide with an existing version.
+-- +goose StatementBegin
+UPDATE authors a
+SET version = v.max
+FROM (
+    SELECT author_id, MAX(version) AS max
+    FROM author_versions
+    GROUP BY author_id
+) v
+WHERE v.author_id = a.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE authors DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
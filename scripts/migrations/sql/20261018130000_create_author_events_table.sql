@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS author_events (
+    id BIGSERIAL PRIMARY KEY,
+    author_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_author_events_unpublished ON author_events (id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_author_events_author_id ON author_events (author_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS author_events;
+-- +goose StatementEnd
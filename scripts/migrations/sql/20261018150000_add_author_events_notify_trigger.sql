@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_author_event() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('author_events', NEW.id::TEXT);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER author_events_notify
+    AFTER INSERT ON author_events
+    FOR EACH ROW EXECUTE FUNCTION notify_author_event();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS author_events_notify ON author_events;
+DROP FUNCTION IF EXISTS notify_author_event();
+-- +goose StatementEnd
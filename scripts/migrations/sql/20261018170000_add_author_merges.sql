@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- name_key folds case and drops punctuation and spaces, so "J.R.R. Tolkien"
+-- and "JRR Tolkien" compare equal.
+ALTER TABLE authors
+    ADD COLUMN IF NOT EXISTS name_key TEXT
+    GENERATED ALWAYS AS (regexp_replace(lower(name), '[^[:alnum:]]+', '', 'g')) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_authors_name_key_trgm ON authors USING GIN (name_key gin_trgm_ops) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS author_redirects (
+    old_id UUID PRIMARY KEY,
+    target_id UUID NOT NULL,
+    merged_by VARCHAR(255),
+    merged_by_username VARCHAR(255),
+    merged_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_author_redirects_target ON author_redirects (target_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS author_redirects;
+DROP INDEX IF EXISTS idx_authors_name_key_trgm;
+ALTER TABLE authors DROP COLUMN IF EXISTS name_key;
+-- +goose StatementEnd
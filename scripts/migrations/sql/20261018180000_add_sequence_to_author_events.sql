@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+-- sequence is the commit order of events. The outbox relay assigns it to
+-- committed rows only, so a reader resuming after a sequence never skips a
+-- transaction that committed late with a lower id. Existing rows are all
+-- committed and keep their id, which keeps old resume tokens valid.
+ALTER TABLE author_events ADD COLUMN IF NOT EXISTS sequence BIGINT;
+
+UPDATE author_events SET sequence = id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_author_events_sequence ON author_events (sequence);
+CREATE INDEX IF NOT EXISTS idx_author_events_unsequenced ON author_events (id) WHERE sequence IS NULL;
+
+-- Readers only see sequenced events, so wake them when the relay assigns
+-- sequences rather than when rows are inserted.
+DROP TRIGGER IF EXISTS author_events_notify ON author_events;
+
+CREATE OR REPLACE FUNCTION notify_author_event() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('author_events', NEW.sequence::TEXT);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER author_events_notify
+    AFTER UPDATE OF sequence ON author_events
+    FOR EACH ROW
+    WHEN (OLD.sequence IS NULL AND NEW.sequence IS NOT NULL)
+    EXECUTE FUNCTION notify_author_event();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS author_events_notify ON author_events;
+
+CREATE OR REPLACE FUNCTION notify_author_event() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('author_events', NEW.id::TEXT);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER author_events_notify
+    AFTER INSERT ON author_events
+    FOR EACH ROW EXECUTE FUNCTION notify_author_event();
+
+DROP INDEX IF EXISTS idx_author_events_unsequenced;
+DROP INDEX IF EXISTS idx_author_events_sequence;
+ALTER TABLE author_events DROP COLUMN IF EXISTS sequence;
+-- +goose StatementEnd
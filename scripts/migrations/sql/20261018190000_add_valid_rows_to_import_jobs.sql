@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- valid_rows counts the rows a dry run found importable. Dry runs used to
+-- report them as created_rows even though nothing was written.
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS valid_rows INT NOT NULL DEFAULT 0;
+
+UPDATE import_jobs SET valid_rows = created_rows, created_rows = 0 WHERE dry_run;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE import_jobs SET created_rows = valid_rows WHERE dry_run;
+
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS valid_rows;
+-- +goose StatementEnd